@@ -31,14 +31,27 @@ func main() {
 		*shortenedString = os.Args[2]
 	}
 
-	basicauth := os.Getenv("BASICAUTH")
-	if basicauth == "" || !strings.Contains(basicauth, ":") {
-		fmt.Fprintf(os.Stderr, "error: invalid BASICAUTH environment value %q\n", basicauth)
-		os.Exit(1)
-		return
-	}
+	// authenticate using APIKEY if provided and fall back to BASICAUTH
+	var authorize client.RequestEditorFn
+	if apiKey := os.Getenv("APIKEY"); apiKey != "" {
+		authorize = func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-API-Key", apiKey)
+			return nil
+		}
+	} else {
+		basicauth := os.Getenv("BASICAUTH")
+		if basicauth == "" || !strings.Contains(basicauth, ":") {
+			fmt.Fprintf(os.Stderr, "error: invalid BASICAUTH environment value %q\n", basicauth)
+			os.Exit(1)
+			return
+		}
 
-	username, password, _ := strings.Cut(basicauth, ":")
+		username, password, _ := strings.Cut(basicauth, ":")
+		authorize = func(ctx context.Context, req *http.Request) error {
+			req.SetBasicAuth(username, password)
+			return nil
+		}
+	}
 
 	ctx := context.Background()
 
@@ -53,10 +66,7 @@ func main() {
 			Url:             link,
 			ShortenedString: shortenedString,
 		},
-		func(ctx context.Context, req *http.Request) error {
-			req.SetBasicAuth(username, password)
-			return nil
-		},
+		authorize,
 	)
 	if err != nil {
 		panic(err)
@@ -12,12 +12,16 @@ import (
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Fprintf(os.Stderr, "error: no shortened string provided.\n")
-		fmt.Printf("usage: %s <shortened string>\n", os.Args[0])
+		fmt.Printf("usage: %s <shortened string> [password]\n", os.Args[0])
 		os.Exit(1)
 		return
 	}
 
 	shortenedString := os.Args[1]
+	params := &client.GetLinkParams{}
+	if len(os.Args) > 2 {
+		params.XLinkPassword = &os.Args[2]
+	}
 
 	ctx := context.Background()
 
@@ -26,7 +30,7 @@ func main() {
 		panic(err)
 	}
 
-	resp, err := client.GetLink(ctx, shortenedString)
+	resp, err := client.GetLink(ctx, shortenedString, params)
 	if err != nil {
 		panic(err)
 	}
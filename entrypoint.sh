@@ -2,10 +2,10 @@
 
 echo "[`date`] Running entrypoint script..."
 
-DSN="postgres://$POSTGRES_USER:$POSTGRES_PASSWORD@$POSTGRES_HOST:$POSTGRES_PORT/$POSTGRES_DB?sslmode=disable"
+DSN="postgres://$POSTGRES_USER:$POSTGRES_PASSWORD@$POSTGRES_HOST:$POSTGRES_PORT/$POSTGRES_DB?sslmode=${POSTGRES_SSLMODE:-disable}"
 
 echo "[`date`] Running DB migrations..."
 migrate -database "${DSN}" -path ./migrations up
 
 echo "[`date`] Starting server..."
-./server
\ No newline at end of file
+exec ./server
\ No newline at end of file
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/deepmap/oapi-codegen/pkg/middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/labstack/echo/v4"
+)
+
+const userContextKey = "auth.user"
+
+// errCredentialsNotProvided is deliberately not an *echo.HTTPError so the
+// request validator reports the error of a scheme the client actually tried
+var errCredentialsNotProvided = errors.New("auth: credentials not provided")
+
+type Authenticator struct {
+	serviceUseCases port.ServiceUseCases
+}
+
+func NewAuthenticator(serviceUseCases port.ServiceUseCases) *Authenticator {
+	return &Authenticator{serviceUseCases: serviceUseCases}
+}
+
+// Authenticate is an openapi3filter.AuthenticationFunc evaluating a single
+// security scheme of the matched operation. on success the authenticated user
+// is stored in the echo context to be read by UserFromContext
+func (a *Authenticator) Authenticate(
+	ctx context.Context,
+	input *openapi3filter.AuthenticationInput,
+) error {
+	c := middleware.GetEchoContext(ctx)
+	if c == nil {
+		return errors.New("auth.Authenticate: echo context not found")
+	}
+
+	var (
+		user *domain.User
+		err  error
+	)
+	scheme := input.SecurityScheme
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		user, err = a.authenticateBasic(c.Request())
+	case scheme.Type == "apiKey" && scheme.In == "header":
+		user, err = a.authenticateAPIKey(c.Request(), scheme.Name)
+	default:
+		return fmt.Errorf(
+			"auth.Authenticate: unsupported security scheme %q",
+			input.SecuritySchemeName,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Set(userContextKey, user)
+	return nil
+}
+
+func (a *Authenticator) authenticateBasic(
+	r *http.Request,
+) (*domain.User, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, errCredentialsNotProvided
+	}
+
+	user, err := a.serviceUseCases.AuthenticateUser(
+		r.Context(),
+		username,
+		password,
+	)
+	if err != nil {
+		return nil, httperror.New(err)
+	}
+
+	return user, nil
+}
+
+func (a *Authenticator) authenticateAPIKey(
+	r *http.Request,
+	header string,
+) (*domain.User, error) {
+	key := r.Header.Get(header)
+	if key == "" {
+		return nil, errCredentialsNotProvided
+	}
+
+	user, err := a.serviceUseCases.AuthenticateAPIKey(r.Context(), key)
+	if err != nil {
+		return nil, httperror.New(err)
+	}
+
+	return user, nil
+}
+
+// ErrorHandler is a request validator middleware.ErrorHandler responding 401
+// instead of the validator's default 403 when no credentials are provided
+func ErrorHandler(c echo.Context, err *echo.HTTPError) error {
+	var securityErr *openapi3filter.SecurityRequirementsError
+	if errors.As(err.Internal, &securityErr) {
+		return echo.NewHTTPError(
+			http.StatusUnauthorized,
+			"authorization not provided",
+		).SetInternal(err.Internal)
+	}
+	return err
+}
+
+// UserFromContext returns the user authenticated by Authenticate
+func UserFromContext(c echo.Context) (*domain.User, bool) {
+	user, ok := c.Get(userContextKey).(*domain.User)
+	return user, ok && user != nil
+}
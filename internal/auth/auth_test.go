@@ -0,0 +1,146 @@
+package auth_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/auth"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/deepmap/oapi-codegen/pkg/middleware"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// setup serves the create_link and list_api_keys operations behind the
+// request validator, responding with the authenticated username
+func setup(t *testing.T, serviceUseCases *mockups.MockServiceUseCases) string {
+	swagger, err := oapi.GetSwagger()
+	require.NoError(t, err)
+	swagger.Servers = nil
+
+	authenticator := auth.NewAuthenticator(serviceUseCases)
+
+	e := echo.New()
+	e.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		ErrorHandler: auth.ErrorHandler,
+		Options: openapi3filter.Options{
+			AuthenticationFunc: authenticator.Authenticate,
+		},
+	}))
+	handler := func(c echo.Context) error {
+		user, ok := auth.UserFromContext(c)
+		if !ok {
+			return c.NoContent(http.StatusTeapot)
+		}
+		return c.JSON(http.StatusOK, echo.Map{"username": user.Username})
+	}
+	e.POST("/link", handler)
+	e.GET("/user/api-keys", handler)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestAuthenticate(t *testing.T) {
+	controller := gomock.NewController(t)
+	serviceUseCases := mockups.NewMockServiceUseCases(controller)
+
+	e := httpexpect.Default(t, setup(t, serviceUseCases))
+
+	body := map[string]string{"url": "http://example.com"}
+
+	// no credentials
+	e.Request(http.MethodPost, "/link").
+		WithJSON(body).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "authorization not provided"})
+
+	// basic authorization
+	serviceUseCases.EXPECT().
+		AuthenticateUser(gomock.Any(), "username", "password").
+		Return(&domain.User{Username: "username"}, nil)
+
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth("username", "password").
+		WithJSON(body).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"username": "username"})
+
+	// invalid basic authorization
+	serviceUseCases.EXPECT().
+		AuthenticateUser(gomock.Any(), "username", "incorrect_password").
+		Return(nil, fmt.Errorf("%w", domain_errors.ErrIncorrectPassword))
+
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth("username", "incorrect_password").
+		WithJSON(body).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "invalid username or password"})
+
+	// api key
+	serviceUseCases.EXPECT().
+		AuthenticateAPIKey(gomock.Any(), "api_key").
+		Return(&domain.User{Username: "username"}, nil)
+
+	e.Request(http.MethodPost, "/link").
+		WithHeader("X-API-Key", "api_key").
+		WithJSON(body).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"username": "username"})
+
+	// invalid api key is reported even though basic authorization is missing
+	serviceUseCases.EXPECT().
+		AuthenticateAPIKey(gomock.Any(), "invalid_api_key").
+		Return(nil, fmt.Errorf("%w", domain_errors.ErrInvalidAPIKey))
+
+	e.Request(http.MethodPost, "/link").
+		WithHeader("X-API-Key", "invalid_api_key").
+		WithJSON(body).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "invalid api key"})
+
+	// api key is not accepted by operations requiring basic authorization
+	e.Request(http.MethodGet, "/user/api-keys").
+		WithHeader("X-API-Key", "api_key").
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "authorization not provided"})
+
+	// unhandled errors
+	serviceUseCases.EXPECT().
+		AuthenticateUser(gomock.Any(), "username", "password").
+		Return(nil, errors.New("AuthenticateUser_unhandled_error"))
+
+	e.Request(http.MethodGet, "/user/api-keys").
+		WithBasicAuth("username", "password").
+		Expect().
+		Status(http.StatusInternalServerError)
+}
@@ -0,0 +1,168 @@
+package clickrecorder
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// column sizes of the clicks table
+const (
+	maxReferrerLength  = 2048
+	maxUserAgentLength = 512
+)
+
+type Options struct {
+	// BatchSize is the max number of clicks saved at once
+	BatchSize int
+	// FlushInterval is the max time a click waits in the queue
+	FlushInterval time.Duration
+	// QueueSize is the number of clicks buffered before new clicks are
+	// dropped, dropped clicks of links without max clicks aren't counted
+	QueueSize int
+}
+
+var DefaultOptions = Options{
+	BatchSize:     100,
+	FlushInterval: time.Second,
+	QueueSize:     10000,
+}
+
+// BatchRecorder saves clicks in batches on a background goroutine so
+// recording never blocks a redirect
+type BatchRecorder struct {
+	repo      port.Repository
+	ipHashKey []byte
+	opts      Options
+
+	queue chan *domain.Click
+	done  chan struct{}
+	// mu guards closed, queue is closed once with it held so Record never
+	// sends on a closed queue
+	mu     sync.RWMutex
+	closed bool
+}
+
+var _ port.ClickRecorder = &BatchRecorder{}
+
+func NewBatchRecorder(
+	repo port.Repository,
+	ipHashKey []byte,
+	opts Options,
+) *BatchRecorder {
+	if opts.BatchSize < 1 {
+		panic("clickrecorder: batch size should be greater than 0")
+	}
+	if opts.FlushInterval <= 0 {
+		panic("clickrecorder: flush interval should be greater than 0")
+	}
+	if opts.QueueSize < 0 {
+		panic("clickrecorder: queue size should not be negative")
+	}
+
+	r := &BatchRecorder{
+		repo:      repo,
+		ipHashKey: ipHashKey,
+		opts:      opts,
+		queue:     make(chan *domain.Click, opts.QueueSize),
+		done:      make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *BatchRecorder) Record(click *domain.Click, clientIP string) {
+	c := *click
+	c.Referrer = truncate(c.Referrer, maxReferrerLength)
+	c.UserAgentFamily = domain.UserAgentFamily(c.UserAgent)
+	c.UserAgent = truncate(c.UserAgent, maxUserAgentLength)
+	c.IPHash = r.hashIP(clientIP)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		log.Printf("clickrecorder.Record: recorder is closed, click on %q dropped", c.ShortenedString)
+		return
+	}
+	select {
+	case r.queue <- &c:
+	default:
+		log.Printf("clickrecorder.Record: queue is full, click on %q dropped", c.ShortenedString)
+	}
+}
+
+// Close stops accepting clicks and blocks until queued clicks are saved.
+// clicks recorded after Close are dropped
+func (r *BatchRecorder) Close() {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+	<-r.done
+}
+
+func (r *BatchRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*domain.Click, 0, r.opts.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.repo.CreateClicks(context.Background(), batch); err != nil {
+			log.Printf("clickrecorder.flush: repository.CreateClicks error: %s", err)
+		}
+		batch = make([]*domain.Click, 0, r.opts.BatchSize)
+	}
+
+	for {
+		select {
+		case click, ok := <-r.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, click)
+			if len(batch) >= r.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// hashIP returns the hex encoded hmac-sha256 of ip so clicks of the same
+// client can be counted without saving the ip
+func (r *BatchRecorder) hashIP(ip string) string {
+	mac := hmac.New(sha256.New, r.ipHashKey)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// truncate returns the valid utf-8 of s cut to at most n bytes on a rune
+// boundary, invalid bytes are replaced by utf8.RuneError
+func truncate(s string, n int) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,260 @@
+package clickrecorder_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/clickrecorder"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var ipHashKey = []byte("ip_hash_key")
+
+func ipHash(ip string) string {
+	mac := hmac.New(sha256.New, ipHashKey)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestRecordFlushesFullBatch(t *testing.T) {
+	require := require.New(t)
+	controller := gomock.NewController(t)
+	repo := mockups.NewMockRepository(controller)
+
+	clickedAt := time.Date(2023, 4, 2, 12, 5, 18, 0, time.UTC)
+	flushed := make(chan []*domain.Click, 1)
+	repo.EXPECT().
+		CreateClicks(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, clicks []*domain.Click) error {
+			flushed <- clicks
+			return nil
+		})
+
+	recorder := clickrecorder.NewBatchRecorder(
+		repo,
+		ipHashKey,
+		clickrecorder.Options{
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+			QueueSize:     10,
+		},
+	)
+	t.Cleanup(recorder.Close)
+
+	recorder.Record(
+		&domain.Click{
+			ShortenedString: "shortened_string",
+			Referrer:        "referrer",
+			UserAgent:       "user_agent",
+			ClickedAt:       clickedAt,
+		},
+		"127.0.0.1",
+	)
+	recorder.Record(
+		&domain.Click{
+			ShortenedString: "shortened_string",
+			ClickedAt:       clickedAt,
+		},
+		"127.0.0.2",
+	)
+
+	select {
+	case clicks := <-flushed:
+		require.Equal(
+			[]*domain.Click{
+				{
+					ShortenedString: "shortened_string",
+					Referrer:        "referrer",
+					UserAgent:       "user_agent",
+					UserAgentFamily: "Other",
+					IPHash:          ipHash("127.0.0.1"),
+					ClickedAt:       clickedAt,
+				},
+				{
+					ShortenedString: "shortened_string",
+					UserAgentFamily: "Unknown",
+					IPHash:          ipHash("127.0.0.2"),
+					ClickedAt:       clickedAt,
+				},
+			},
+			clicks,
+		)
+	case <-time.After(time.Second):
+		t.Fatal("batch not flushed")
+	}
+}
+
+func TestRecordFlushesOnInterval(t *testing.T) {
+	controller := gomock.NewController(t)
+	repo := mockups.NewMockRepository(controller)
+
+	flushed := make(chan struct{})
+	repo.EXPECT().
+		CreateClicks(gomock.Any(), gomock.Len(1)).
+		DoAndReturn(func(_ context.Context, clicks []*domain.Click) error {
+			close(flushed)
+			return nil
+		})
+
+	recorder := clickrecorder.NewBatchRecorder(
+		repo,
+		ipHashKey,
+		clickrecorder.Options{
+			BatchSize:     100,
+			FlushInterval: 10 * time.Millisecond,
+			QueueSize:     10,
+		},
+	)
+	t.Cleanup(recorder.Close)
+
+	recorder.Record(&domain.Click{ShortenedString: "shortened_string"}, "127.0.0.1")
+
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("batch not flushed")
+	}
+}
+
+func TestCloseFlushesQueuedClicks(t *testing.T) {
+	require := require.New(t)
+	controller := gomock.NewController(t)
+	repo := mockups.NewMockRepository(controller)
+
+	var saved []*domain.Click
+	repo.EXPECT().
+		CreateClicks(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, clicks []*domain.Click) error {
+			saved = append(saved, clicks...)
+			return nil
+		}).
+		AnyTimes()
+
+	recorder := clickrecorder.NewBatchRecorder(
+		repo,
+		ipHashKey,
+		clickrecorder.Options{
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+			QueueSize:     10,
+		},
+	)
+
+	for i := 0; i < 5; i++ {
+		recorder.Record(
+			&domain.Click{
+				ShortenedString: "shortened_string",
+				Referrer:        strings.Repeat("r", 3000),
+				UserAgent:       strings.Repeat("u", 1000),
+			},
+			"127.0.0.1",
+		)
+	}
+	recorder.Close()
+
+	require.Len(saved, 5)
+	for _, click := range saved {
+		// values are truncated to fit clicks table columns
+		require.Len(click.Referrer, 2048)
+		require.Len(click.UserAgent, 512)
+	}
+}
+
+func TestRecordAfterClose(t *testing.T) {
+	controller := gomock.NewController(t)
+	repo := mockups.NewMockRepository(controller)
+
+	// clicks recorded after close are dropped, not saved or panicking
+	repo.EXPECT().CreateClicks(gomock.Any(), gomock.Any()).Times(0)
+
+	recorder := clickrecorder.NewBatchRecorder(
+		repo,
+		ipHashKey,
+		clickrecorder.DefaultOptions,
+	)
+	recorder.Close()
+
+	require.NotPanics(t, func() {
+		recorder.Record(
+			&domain.Click{ShortenedString: "shortened_string"},
+			"127.0.0.1",
+		)
+	})
+	recorder.Close()
+}
+
+func TestRecordTruncatesOnRuneBoundaries(t *testing.T) {
+	require := require.New(t)
+	controller := gomock.NewController(t)
+	repo := mockups.NewMockRepository(controller)
+
+	var saved []*domain.Click
+	repo.EXPECT().
+		CreateClicks(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, clicks []*domain.Click) error {
+			saved = append(saved, clicks...)
+			return nil
+		})
+
+	recorder := clickrecorder.NewBatchRecorder(
+		repo,
+		ipHashKey,
+		clickrecorder.Options{
+			BatchSize:     1,
+			FlushInterval: time.Hour,
+			QueueSize:     1,
+		},
+	)
+
+	// the two bytes of "é" straddle the referrer limit and the user agent
+	// has an invalid byte
+	recorder.Record(
+		&domain.Click{
+			ShortenedString: "shortened_string",
+			Referrer:        strings.Repeat("r", 2047) + "é",
+			UserAgent:       "agent\xff" + strings.Repeat("世", 200),
+		},
+		"127.0.0.1",
+	)
+	recorder.Close()
+
+	require.Len(saved, 1)
+	require.Equal(strings.Repeat("r", 2047), saved[0].Referrer)
+	require.True(utf8.ValidString(saved[0].UserAgent))
+	require.LessOrEqual(len(saved[0].UserAgent), 512)
+	require.Equal(
+		"agent\uFFFD"+strings.Repeat("世", 168),
+		saved[0].UserAgent,
+	)
+}
+
+func TestNewBatchRecorderPanics(t *testing.T) {
+	require := require.New(t)
+	repo := mockups.NewMockRepository(gomock.NewController(t))
+
+	require.PanicsWithValue(
+		"clickrecorder: batch size should be greater than 0",
+		func() {
+			clickrecorder.NewBatchRecorder(repo, ipHashKey, clickrecorder.Options{
+				FlushInterval: time.Second,
+			})
+		},
+	)
+	require.PanicsWithValue(
+		"clickrecorder: flush interval should be greater than 0",
+		func() {
+			clickrecorder.NewBatchRecorder(repo, ipHashKey, clickrecorder.Options{
+				BatchSize: 1,
+			})
+		},
+	)
+}
@@ -0,0 +1,753 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/generator"
+	"github.com/aria3ppp/url-shortener-openapi/internal/lifecycle"
+	"github.com/aria3ppp/url-shortener-openapi/internal/linkcache"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the service, see Load
+type Config struct {
+	Server    Server
+	Log       Log
+	Storage   Storage
+	Cache     Cache
+	Generator Generator
+	Users     Users
+	Links     Links
+}
+
+type Server struct {
+	Port int
+	// BaseURL is the url the service is reached at by clients, pages link
+	// to relative paths if it's empty
+	BaseURL string
+	// TLS is served if both files are given
+	TLS                TLS
+	ShutdownTimeout    time.Duration
+	ShutdownDrainDelay time.Duration
+	// TrustedProxies are the comma separated ips or cidrs of the reverse
+	// proxies whose X-Forwarded-For is trusted, clients are the connection
+	// peers if it's empty
+	TrustedProxies string
+}
+
+// TrustedProxyNets returns the networks of TrustedProxies
+func (s Server) TrustedProxyNets() ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, proxy := range strings.Split(s.TrustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %q", proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+type TLS struct {
+	CertFile string
+	KeyFile  string
+}
+
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+type Log struct {
+	// Level is one of debug, info, warn, error or off
+	Level string
+}
+
+type Storage struct {
+	// Driver is one of postgres, sqlite or memory
+	Driver string
+	// QueryTimeout bounds every query, 0 disables it
+	QueryTimeout time.Duration
+	Postgres     Postgres
+	SQLite       SQLite
+	Pool         Pool
+}
+
+type Postgres struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DB       string
+	SSLMode  string
+}
+
+// DSN returns the url connecting to the database
+func (p Postgres) DSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(p.User, p.Password),
+		Host:     net.JoinHostPort(p.Host, strconv.Itoa(p.Port)),
+		Path:     "/" + p.DB,
+		RawQuery: url.Values{"sslmode": {p.SSLMode}}.Encode(),
+	}
+	return dsn.String()
+}
+
+type SQLite struct {
+	Path string
+}
+
+// Pool settings of the database connections, zero values are the defaults of
+// database/sql
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+type Cache struct {
+	// Driver is one of memory, redis or none
+	Driver      string
+	Size        int
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	Redis       Redis
+}
+
+type Redis struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+type Generator struct {
+	// Kind is one of random or sequence
+	Kind   string
+	Length int
+	// Alphabet is a name of generator.Alphabets or the characters of a custom
+	// alphabet
+	Alphabet string
+	// SequenceKey obfuscates sequence codes, they're in order if it's empty
+	SequenceKey       string
+	SequenceBlockSize int
+}
+
+// AlphabetChars returns the characters of Alphabet
+func (g Generator) AlphabetChars() string {
+	if predefined, exists := generator.Alphabets[g.Alphabet]; exists {
+		return predefined
+	}
+	return g.Alphabet
+}
+
+type Users struct {
+	// PasswordHasher is one of bcrypt or argon2id
+	PasswordHasher string
+}
+
+type Links struct {
+	DefaultRedirectType int
+	// ClickIPHashKey is the key of click ip hashes, a random key is used per
+	// run if it's empty
+	ClickIPHashKey string
+}
+
+var Default = Config{
+	Server: Server{
+		Port:            8080,
+		ShutdownTimeout: lifecycle.DefaultOptions.ShutdownTimeout,
+	},
+	Log: Log{
+		// as echo logs by default
+		Level: "error",
+	},
+	Storage: Storage{
+		Driver:       "postgres",
+		QueryTimeout: 5 * time.Second,
+		Postgres: Postgres{
+			Host:    "localhost",
+			Port:    5432,
+			SSLMode: "disable",
+		},
+		SQLite: SQLite{
+			Path: "url-shortener.db",
+		},
+		Pool: Pool{
+			// as database/sql does by default
+			MaxIdleConns: 2,
+		},
+	},
+	Cache: Cache{
+		Driver:      "memory",
+		Size:        10000,
+		TTL:         repository.DefaultCacheOptions.TTL,
+		NegativeTTL: repository.DefaultCacheOptions.NegativeTTL,
+		Redis: Redis{
+			Addr: linkcache.DefaultRedisOptions.Addr,
+		},
+	},
+	Generator: Generator{
+		Kind:              "random",
+		Length:            6,
+		Alphabet:          "base62",
+		SequenceBlockSize: generator.DefaultSequenceBlockSize,
+	},
+	Users: Users{
+		PasswordHasher: "bcrypt",
+	},
+	Links: Links{
+		// a temporary redirect, so clients do not cache links and every click
+		// reaches the server
+		DefaultRedirectType: int(domain.RedirectFound),
+	},
+}
+
+// field is a setting loaded by its dotted key off a file and by its flag,
+// which is named the key too unless it's set, and its env
+type field struct {
+	key    string
+	flag   string
+	env    string
+	usage  string
+	secret bool
+	value  value
+}
+
+// value is a setting parsed from and formatted to a string
+type value interface {
+	Set(string) error
+	String() string
+}
+
+// fields returns the settings of c, in the order they're printed
+func (c *Config) fields() []field {
+	return []field{
+		{
+			key:   "server.port",
+			env:   "SERVER_PORT",
+			usage: "port to serve on",
+			value: intValue{&c.Server.Port},
+		},
+		{
+			key:   "server.base_url",
+			env:   "BASE_URL",
+			usage: "url the service is reached at by clients",
+			value: stringValue{&c.Server.BaseURL},
+		},
+		{
+			key:   "server.tls.cert_file",
+			env:   "TLS_CERT_FILE",
+			usage: "certificate file served over tls",
+			value: stringValue{&c.Server.TLS.CertFile},
+		},
+		{
+			key:   "server.tls.key_file",
+			env:   "TLS_KEY_FILE",
+			usage: "private key file of the tls certificate",
+			value: stringValue{&c.Server.TLS.KeyFile},
+		},
+		{
+			key:   "server.shutdown_timeout",
+			env:   "SHUTDOWN_TIMEOUT",
+			usage: "time requests in flight are waited and queued clicks saved on shutdown",
+			value: durationValue{&c.Server.ShutdownTimeout},
+		},
+		{
+			key:   "server.shutdown_drain_delay",
+			env:   "SHUTDOWN_DRAIN_DELAY",
+			usage: "time readiness fails before shutting down",
+			value: durationValue{&c.Server.ShutdownDrainDelay},
+		},
+		{
+			key:   "server.trusted_proxies",
+			env:   "TRUSTED_PROXIES",
+			usage: "comma separated ips or cidrs of reverse proxies whose X-Forwarded-For is trusted",
+			value: stringValue{&c.Server.TrustedProxies},
+		},
+		{
+			key:   "log.level",
+			env:   "LOG_LEVEL",
+			usage: "log level: debug, info, warn, error or off",
+			value: stringValue{&c.Log.Level},
+		},
+		{
+			key:   "storage.driver",
+			flag:  "storage",
+			env:   "STORAGE_DRIVER",
+			usage: "storage driver: postgres, sqlite or memory",
+			value: stringValue{&c.Storage.Driver},
+		},
+		{
+			key:   "storage.query_timeout",
+			env:   "QUERY_TIMEOUT",
+			usage: "timeout of each storage query, 0 disables it",
+			value: durationValue{&c.Storage.QueryTimeout},
+		},
+		{
+			key:   "storage.postgres.host",
+			env:   "POSTGRES_HOST",
+			usage: "postgres host",
+			value: stringValue{&c.Storage.Postgres.Host},
+		},
+		{
+			key:   "storage.postgres.port",
+			env:   "POSTGRES_PORT",
+			usage: "postgres port",
+			value: intValue{&c.Storage.Postgres.Port},
+		},
+		{
+			key:   "storage.postgres.user",
+			env:   "POSTGRES_USER",
+			usage: "postgres user",
+			value: stringValue{&c.Storage.Postgres.User},
+		},
+		{
+			key:    "storage.postgres.password",
+			env:    "POSTGRES_PASSWORD",
+			usage:  "postgres password",
+			secret: true,
+			value:  stringValue{&c.Storage.Postgres.Password},
+		},
+		{
+			key:   "storage.postgres.db",
+			env:   "POSTGRES_DB",
+			usage: "postgres database",
+			value: stringValue{&c.Storage.Postgres.DB},
+		},
+		{
+			key:   "storage.postgres.ssl_mode",
+			env:   "POSTGRES_SSLMODE",
+			usage: "postgres sslmode: disable, allow, prefer, require, verify-ca or verify-full",
+			value: stringValue{&c.Storage.Postgres.SSLMode},
+		},
+		{
+			key:   "storage.sqlite.path",
+			env:   "SQLITE_PATH",
+			usage: "sqlite database file",
+			value: stringValue{&c.Storage.SQLite.Path},
+		},
+		{
+			key:   "storage.pool.max_open_conns",
+			env:   "DB_MAX_OPEN_CONNS",
+			usage: "max open database connections, 0 is unlimited",
+			value: intValue{&c.Storage.Pool.MaxOpenConns},
+		},
+		{
+			key:   "storage.pool.max_idle_conns",
+			env:   "DB_MAX_IDLE_CONNS",
+			usage: "max idle database connections",
+			value: intValue{&c.Storage.Pool.MaxIdleConns},
+		},
+		{
+			key:   "storage.pool.conn_max_lifetime",
+			env:   "DB_CONN_MAX_LIFETIME",
+			usage: "max time a database connection is reused, 0 is unlimited",
+			value: durationValue{&c.Storage.Pool.ConnMaxLifetime},
+		},
+		{
+			key:   "storage.pool.conn_max_idle_time",
+			env:   "DB_CONN_MAX_IDLE_TIME",
+			usage: "max time a database connection is idle, 0 is unlimited",
+			value: durationValue{&c.Storage.Pool.ConnMaxIdleTime},
+		},
+		{
+			key:   "cache.driver",
+			env:   "LINK_CACHE",
+			usage: "link lookup cache: memory, redis or none",
+			value: stringValue{&c.Cache.Driver},
+		},
+		{
+			key:   "cache.size",
+			env:   "LINK_CACHE_SIZE",
+			usage: "links cached in memory",
+			value: intValue{&c.Cache.Size},
+		},
+		{
+			key:   "cache.ttl",
+			env:   "LINK_CACHE_TTL",
+			usage: "time links stay cached, their click counts lag as long",
+			value: durationValue{&c.Cache.TTL},
+		},
+		{
+			key:   "cache.negative_ttl",
+			env:   "LINK_CACHE_NEGATIVE_TTL",
+			usage: "time lookups of links which don't exist stay cached",
+			value: durationValue{&c.Cache.NegativeTTL},
+		},
+		{
+			key:   "cache.redis.addr",
+			env:   "REDIS_ADDR",
+			usage: "redis address",
+			value: stringValue{&c.Cache.Redis.Addr},
+		},
+		{
+			key:    "cache.redis.password",
+			env:    "REDIS_PASSWORD",
+			usage:  "redis password",
+			secret: true,
+			value:  stringValue{&c.Cache.Redis.Password},
+		},
+		{
+			key:   "cache.redis.db",
+			env:   "REDIS_DB",
+			usage: "redis database",
+			value: intValue{&c.Cache.Redis.DB},
+		},
+		{
+			key:   "generator.kind",
+			env:   "SHORT_CODE_GENERATOR",
+			usage: "short code generator: random or sequence",
+			value: stringValue{&c.Generator.Kind},
+		},
+		{
+			key:   "generator.length",
+			env:   "SHORT_CODE_LENGTH",
+			usage: "length of generated short codes, between 6 and 32",
+			value: intValue{&c.Generator.Length},
+		},
+		{
+			key:   "generator.alphabet",
+			env:   "SHORT_CODE_ALPHABET",
+			usage: "alphabet of generated short codes, a predefined alphabet name or its characters",
+			value: stringValue{&c.Generator.Alphabet},
+		},
+		{
+			key:    "generator.sequence_key",
+			env:    "SHORT_CODE_SEQUENCE_KEY",
+			usage:  "secret key obfuscating sequence short codes",
+			secret: true,
+			value:  stringValue{&c.Generator.SequenceKey},
+		},
+		{
+			key:   "generator.sequence_block_size",
+			env:   "SHORT_CODE_SEQUENCE_BLOCK_SIZE",
+			usage: "sequence ids reserved at once",
+			value: intValue{&c.Generator.SequenceBlockSize},
+		},
+		{
+			key:   "users.password_hasher",
+			env:   "PASSWORD_HASHER",
+			usage: "password hasher: bcrypt or argon2id",
+			value: stringValue{&c.Users.PasswordHasher},
+		},
+		{
+			key:   "links.default_redirect_type",
+			env:   "DEFAULT_REDIRECT_TYPE",
+			usage: "redirect status code of links without a redirect type: 301, 302, 307 or 308",
+			value: intValue{&c.Links.DefaultRedirectType},
+		},
+		{
+			key:    "links.click_ip_hash_key",
+			env:    "CLICK_IP_HASH_KEY",
+			usage:  "secret key of click ip hashes, random per run if empty",
+			secret: true,
+			value:  stringValue{&c.Links.ClickIPHashKey},
+		},
+	}
+}
+
+type stringValue struct{ p *string }
+
+func (v stringValue) Set(s string) error {
+	*v.p = s
+	return nil
+}
+
+func (v stringValue) String() string { return *v.p }
+
+type intValue struct{ p *int }
+
+func (v intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	*v.p = n
+	return nil
+}
+
+func (v intValue) String() string { return strconv.Itoa(*v.p) }
+
+type durationValue struct{ p *time.Duration }
+
+func (v durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*v.p = d
+	return nil
+}
+
+func (v durationValue) String() string { return v.p.String() }
+
+// Validate returns the invalid settings by their keys
+func (c Config) Validate() error {
+	positive := validation.Min(time.Duration(1)).Error("must be positive")
+	notNegative := validation.Min(0).Error("must not be negative")
+	notNegativeDuration := validation.Min(time.Duration(0)).
+		Error("must not be negative")
+	isPostgres := c.Storage.Driver == "postgres"
+
+	return validation.Errors{
+		"server.port": validation.Validate(
+			c.Server.Port,
+			validation.Required,
+			validation.Min(1),
+			validation.Max(65535),
+		),
+		"server.base_url": validation.Validate(c.Server.BaseURL, is.URL),
+		"server.tls.cert_file": validation.Validate(
+			c.Server.TLS.CertFile,
+			validation.When(
+				c.Server.TLS.KeyFile != "",
+				validation.Required.Error("is required with a key file"),
+			),
+		),
+		"server.tls.key_file": validation.Validate(
+			c.Server.TLS.KeyFile,
+			validation.When(
+				c.Server.TLS.CertFile != "",
+				validation.Required.Error("is required with a cert file"),
+			),
+		),
+		"server.shutdown_timeout": validation.Validate(
+			c.Server.ShutdownTimeout,
+			validation.Required,
+			positive,
+		),
+		"server.shutdown_drain_delay": validation.Validate(
+			c.Server.ShutdownDrainDelay,
+			notNegativeDuration,
+		),
+		"server.trusted_proxies": validation.Validate(
+			c.Server.TrustedProxies,
+			validation.By(func(interface{}) error {
+				_, err := c.Server.TrustedProxyNets()
+				return err
+			}),
+		),
+		"log.level": validation.Validate(
+			c.Log.Level,
+			validation.Required,
+			validation.In("debug", "info", "warn", "error", "off"),
+		),
+		"storage.driver": validation.Validate(
+			c.Storage.Driver,
+			validation.Required,
+			validation.In("postgres", "sqlite", "memory"),
+		),
+		"storage.query_timeout": validation.Validate(
+			c.Storage.QueryTimeout,
+			notNegativeDuration,
+		),
+		"storage.postgres.host": validation.Validate(
+			c.Storage.Postgres.Host,
+			validation.When(isPostgres, validation.Required),
+		),
+		"storage.postgres.port": validation.Validate(
+			c.Storage.Postgres.Port,
+			validation.When(isPostgres, validation.Required),
+			validation.Min(1),
+			validation.Max(65535),
+		),
+		"storage.postgres.user": validation.Validate(
+			c.Storage.Postgres.User,
+			validation.When(isPostgres, validation.Required),
+		),
+		"storage.postgres.db": validation.Validate(
+			c.Storage.Postgres.DB,
+			validation.When(isPostgres, validation.Required),
+		),
+		"storage.postgres.ssl_mode": validation.Validate(
+			c.Storage.Postgres.SSLMode,
+			validation.When(isPostgres, validation.Required),
+			validation.In(
+				"disable",
+				"allow",
+				"prefer",
+				"require",
+				"verify-ca",
+				"verify-full",
+			),
+		),
+		"storage.sqlite.path": validation.Validate(
+			c.Storage.SQLite.Path,
+			validation.When(c.Storage.Driver == "sqlite", validation.Required),
+		),
+		"storage.pool.max_open_conns": validation.Validate(
+			c.Storage.Pool.MaxOpenConns,
+			notNegative,
+		),
+		"storage.pool.max_idle_conns": validation.Validate(
+			c.Storage.Pool.MaxIdleConns,
+			notNegative,
+		),
+		"storage.pool.conn_max_lifetime": validation.Validate(
+			c.Storage.Pool.ConnMaxLifetime,
+			notNegativeDuration,
+		),
+		"storage.pool.conn_max_idle_time": validation.Validate(
+			c.Storage.Pool.ConnMaxIdleTime,
+			notNegativeDuration,
+		),
+		"cache.driver": validation.Validate(
+			c.Cache.Driver,
+			validation.Required,
+			validation.In("memory", "redis", "none"),
+		),
+		"cache.size": validation.Validate(
+			c.Cache.Size,
+			validation.When(c.Cache.Driver == "memory", validation.Required),
+			validation.Min(1),
+		),
+		"cache.ttl": validation.Validate(
+			c.Cache.TTL,
+			validation.Required,
+			positive,
+		),
+		"cache.negative_ttl": validation.Validate(
+			c.Cache.NegativeTTL,
+			validation.Required,
+			positive,
+		),
+		"cache.redis.addr": validation.Validate(
+			c.Cache.Redis.Addr,
+			validation.When(c.Cache.Driver == "redis", validation.Required),
+		),
+		"cache.redis.db": validation.Validate(c.Cache.Redis.DB, notNegative),
+		"generator.kind": validation.Validate(
+			c.Generator.Kind,
+			validation.Required,
+			validation.In("random", "sequence"),
+		),
+		"generator.length": validation.Validate(
+			c.Generator.Length,
+			validation.Required,
+			validation.Min(6),
+			validation.Max(32),
+			// sequence ids are 64 bits
+			validation.When(
+				c.Generator.Kind == "sequence",
+				validation.By(func(interface{}) error {
+					return generator.ValidateSequenceKeyspace(
+						c.Generator.AlphabetChars(),
+						c.Generator.Length,
+					)
+				}),
+			),
+		),
+		"generator.alphabet": validation.Validate(
+			c.Generator.Alphabet,
+			validation.Required,
+			validation.By(func(interface{}) error {
+				return generator.ValidateAlphabet(c.Generator.AlphabetChars())
+			}),
+		),
+		"generator.sequence_block_size": validation.Validate(
+			c.Generator.SequenceBlockSize,
+			validation.When(c.Generator.Kind == "sequence", validation.Required),
+			validation.Min(1),
+		),
+		"users.password_hasher": validation.Validate(
+			c.Users.PasswordHasher,
+			validation.Required,
+			validation.In("bcrypt", "argon2id"),
+		),
+		"links.default_redirect_type": validation.Validate(
+			c.Links.DefaultRedirectType,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				if !domain.RedirectType(value.(int)).Valid() {
+					return validation.NewError(
+						"validation_redirect_type",
+						"must be 301, 302, 307 or 308",
+					)
+				}
+				return nil
+			}),
+		),
+	}.Filter()
+}
+
+// redacted replaces secrets which are set when printed
+const redacted = "REDACTED"
+
+// String returns c as a yaml config file, secrets redacted
+func (c Config) String() string {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range c.fields() {
+		value := f.value.String()
+		if f.secret && value != "" {
+			value = redacted
+		}
+
+		// find or add the mappings of the key sections
+		node := root
+		sections := strings.Split(f.key, ".")
+		for _, section := range sections[:len(sections)-1] {
+			node = mapping(node, section)
+		}
+
+		tag := "!!str"
+		if _, isInt := f.value.(intValue); isInt {
+			tag = "!!int"
+		}
+		node.Content = append(
+			node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: sections[len(sections)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+		)
+	}
+
+	var out strings.Builder
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		// nodes of scalars always encode
+		panic(err)
+	}
+	return out.String()
+}
+
+// mapping returns the mapping of key in node, it's added if missing
+func mapping(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(
+		node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		child,
+	)
+	return child
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Options are the flags of Load which are not settings
+type Options struct {
+	// PrintConfig asks to print the config and exit
+	PrintConfig bool
+}
+
+// Load returns the defaults overridden by the config file, then the env and
+// then the flags of args. the file is given by the -config flag or the
+// CONFIG_FILE env and is read as yaml or toml by its extension. usage and
+// flag errors are written to output, flag.ErrHelp is returned if asked for
+func Load(
+	name string,
+	args []string,
+	getenv func(string) string,
+	output io.Writer,
+) (Config, Options, error) {
+	config := Default
+	fields := config.fields()
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(output)
+	file := flags.String(
+		"config",
+		getenv("CONFIG_FILE"),
+		"yaml or toml config file, overridden by the env and the flags (env CONFIG_FILE)",
+	)
+	var opts Options
+	flags.BoolVar(
+		&opts.PrintConfig,
+		"print-config",
+		false,
+		"print the config, secrets redacted, and exit",
+	)
+	// flag values are recorded to be set after the file and the env
+	byFlag := make(map[string]field, len(fields))
+	for _, f := range fields {
+		name := f.flag
+		if name == "" {
+			name = f.key
+		}
+		byFlag[name] = f
+		usage := fmt.Sprintf("%s (env %s)", f.usage, f.env)
+		if f.secret {
+			// command lines are visible to other users of the host
+			usage = fmt.Sprintf(
+				"%s, better set by env %s or the config file than this flag",
+				f.usage,
+				f.env,
+			)
+		}
+		flags.Var(&flagValue{def: f.value.String()}, name, usage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return Config{}, Options{}, err
+	}
+	if flags.NArg() > 0 {
+		return Config{}, Options{}, fmt.Errorf(
+			"config: unexpected arguments %q",
+			flags.Args(),
+		)
+	}
+
+	if *file != "" {
+		if err := loadFile(*file, fields); err != nil {
+			return Config{}, Options{}, err
+		}
+	}
+
+	for _, f := range fields {
+		if s := getenv(f.env); s != "" {
+			if err := f.value.Set(s); err != nil {
+				return Config{}, Options{}, fmt.Errorf("config: %s: %w", f.env, err)
+			}
+		}
+	}
+
+	var err error
+	flags.Visit(func(fl *flag.Flag) {
+		f, exists := byFlag[fl.Name]
+		if !exists || err != nil {
+			return
+		}
+		if setErr := f.value.Set(fl.Value.String()); setErr != nil {
+			err = fmt.Errorf("config: -%s: %w", fl.Name, setErr)
+		}
+	})
+	if err != nil {
+		return Config{}, Options{}, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, Options{}, fmt.Errorf("config: %w", err)
+	}
+	return config, opts, nil
+}
+
+// flagValue records the value of a flag
+type flagValue struct {
+	def   string
+	value *string
+}
+
+func (v *flagValue) Set(s string) error {
+	v.value = &s
+	return nil
+}
+
+func (v *flagValue) String() string {
+	if v.value == nil {
+		return v.def
+	}
+	return *v.value
+}
+
+// loadFile sets fields by the keys of the config file at path
+func loadFile(path string, fields []field) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	document := make(map[string]any)
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return fmt.Errorf("config: %s: unsupported file extension %q", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten("", document, values); err != nil {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	for _, f := range fields {
+		if s, exists := values[f.key]; exists {
+			if err := f.value.Set(s); err != nil {
+				return fmt.Errorf("config: %s: %s: %w", path, f.key, err)
+			}
+			delete(values, f.key)
+		}
+	}
+
+	// typos would be silently ignored otherwise
+	unknown := make([]string, 0, len(values))
+	for key := range values {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	if len(unknown) > 0 {
+		return fmt.Errorf("config: %s: unknown keys %q", path, unknown)
+	}
+	return nil
+}
+
+// flatten adds the scalars of document to values by their dotted keys
+func flatten(prefix string, document map[string]any, values map[string]string) error {
+	for key, value := range document {
+		key = prefix + key
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flatten(key+".", value, values); err != nil {
+				return err
+			}
+		case nil:
+			values[key] = ""
+		case string, int, int64, uint64, float64, bool:
+			values[key] = fmt.Sprint(value)
+		default:
+			return fmt.Errorf("%s: should be a string, a number or a boolean", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+type APIKey struct {
+	Prefix     string     `json:"prefix"` // unique, used to look up the key
+	Hash       string     `json:"-"`      // sha256 of the whole key, the key itself is never saved
+	Name       string     `json:"name"`
+	Username   string     `json:"username"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+func (k APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
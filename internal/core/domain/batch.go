@@ -0,0 +1,8 @@
+package domain
+
+// LinkResult is the outcome of creating a link of a batch, Link is nil if Err
+// is not
+type LinkResult struct {
+	Link *Link
+	Err  error
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type Click struct {
+	ShortenedString string    `json:"shortened_string"`
+	Referrer        string    `json:"referrer"`
+	UserAgent       string    `json:"user_agent"`
+	UserAgentFamily string    `json:"user_agent_family"`
+	IPHash          string    `json:"ip_hash"` // keyed hash of the client ip, the ip itself is never saved
+	ClickedAt       time.Time `json:"clicked_at"`
+	// Counted tells the click is in the click count of its link already,
+	// clicks which aren't are added to it as they're saved
+	Counted bool `json:"-"`
+}
+
+// user agent families ordered by precedence: most browsers mention the
+// engines of others (e.g. edge claims to be chrome and safari)
+var userAgentFamilies = []struct {
+	family  string
+	markers []string
+}{
+	{"Bot", []string{"bot", "crawler", "spider", "slurp"}},
+	{"curl", []string{"curl/"}},
+	{"Edge", []string{"edg/", "edge/", "edga/", "edgios/"}},
+	{"Opera", []string{"opr/", "opera"}},
+	{"Firefox", []string{"firefox/", "fxios/"}},
+	{"Chrome", []string{"chrome/", "crios/", "chromium/"}},
+	{"Safari", []string{"safari/"}},
+}
+
+// UserAgentFamily returns the browser family of a user agent header
+func UserAgentFamily(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown"
+	}
+	ua := strings.ToLower(userAgent)
+	for _, f := range userAgentFamilies {
+		for _, marker := range f.markers {
+			if strings.Contains(ua, marker) {
+				return f.family
+			}
+		}
+	}
+	return "Other"
+}
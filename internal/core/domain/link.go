@@ -1,29 +1,37 @@
 package domain
 
-import (
-	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/go-ozzo/ozzo-validation/v4/is"
-)
+import "time"
 
 type Link struct {
 	ShortenedString string `json:"shortened_string"` // unique
 	URL             string `json:"url"`
 	Username        string `json:"username"`
+	// set by the repository on creation
+	CreatedAt time.Time `json:"created_at"`
+	// optional lifecycle limits, the link expires once either is reached
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	MaxClicks  *int       `json:"max_clicks,omitempty"`
+	ClickCount int        `json:"click_count"`
+	// the server default is used if nil
+	RedirectType *RedirectType `json:"redirect_type,omitempty"`
+	// access password saved as an encoded hash produced by a
+	// port.PasswordHasher, the link is public if empty
+	Password string `json:"-"`
 }
 
-var _ validation.Validatable = Link{}
+// Protected reports whether the link needs a password to be followed
+func (r Link) Protected() bool {
+	return r.Password != ""
+}
+
+// ClickLimited reports whether the link has max clicks, its clicks are then
+// counted as it's followed so the limit is never exceeded
+func (r Link) ClickLimited() bool {
+	return r.MaxClicks != nil
+}
 
-func (r Link) Validate() error {
-	return validation.ValidateStruct(
-		&r,
-		validation.Field(
-			&r.URL,
-			validation.Required,
-			is.URL,
-		),
-		validation.Field(
-			&r.ShortenedString,
-			is.Alphanumeric,
-		),
-	)
+// Expired reports whether either lifecycle limit of the link is reached
+func (r Link) Expired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt) ||
+		r.MaxClicks != nil && r.ClickCount >= *r.MaxClicks
 }
@@ -0,0 +1,31 @@
+package domain
+
+// RedirectType is the http status code a link redirects with
+type RedirectType int
+
+const (
+	// RedirectDefault is not a status code, it resets links to the server
+	// default redirect type on update
+	RedirectDefault RedirectType = 0
+
+	RedirectMovedPermanently  RedirectType = 301
+	RedirectFound             RedirectType = 302
+	RedirectTemporaryRedirect RedirectType = 307
+	RedirectPermanentRedirect RedirectType = 308
+)
+
+func (t RedirectType) Valid() bool {
+	switch t {
+	case RedirectMovedPermanently,
+		RedirectFound,
+		RedirectTemporaryRedirect,
+		RedirectPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// Permanent reports whether clients are allowed to cache the redirect
+func (t RedirectType) Permanent() bool {
+	return t == RedirectMovedPermanently || t == RedirectPermanentRedirect
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+type StatsInterval string
+
+const (
+	StatsIntervalHour StatsInterval = "hour"
+	StatsIntervalDay  StatsInterval = "day"
+	StatsIntervalWeek StatsInterval = "week"
+)
+
+func (i StatsInterval) Valid() bool {
+	switch i {
+	case StatsIntervalHour, StatsIntervalDay, StatsIntervalWeek:
+		return true
+	}
+	return false
+}
+
+// Truncate returns the start of the utc bucket t falls in; weeks start on
+// monday like postgres date_trunc
+func (i StatsInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case StatsIntervalHour:
+		return t.Truncate(time.Hour)
+	case StatsIntervalDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case StatsIntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	panic("domain: invalid stats interval " + string(i))
+}
+
+// Next returns the start of the bucket following the one starting at start
+func (i StatsInterval) Next(start time.Time) time.Time {
+	switch i {
+	case StatsIntervalHour:
+		return start.Add(time.Hour)
+	case StatsIntervalDay:
+		return start.AddDate(0, 0, 1)
+	case StatsIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	}
+	panic("domain: invalid stats interval " + string(i))
+}
+
+type StatsBucket struct {
+	Start          time.Time `json:"start"`
+	Clicks         int       `json:"clicks"`
+	UniqueVisitors int       `json:"unique_visitors"`
+}
+
+type StatsCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type LinkStats struct {
+	From           time.Time      `json:"from"`
+	To             time.Time      `json:"to"`
+	Interval       StatsInterval  `json:"interval"`
+	TotalClicks    int            `json:"total_clicks"`
+	UniqueVisitors int            `json:"unique_visitors"`
+	Series         []*StatsBucket `json:"series"`
+	TopReferrers   []*StatsCount  `json:"top_referrers"`
+	TopUserAgents  []*StatsCount  `json:"top_user_agents"`
+}
@@ -4,8 +4,16 @@ import "errors"
 
 var (
 	ErrLinkNotFound        = errors.New("link not found")
+	ErrLinkNotOwned        = errors.New("link not owned")
+	ErrLinkExpired         = errors.New("link expired")
+	ErrLinkLocked          = errors.New("link locked")
 	ErrUserNotFound        = errors.New("user not found")
 	ErrUsernameTaken       = errors.New("username taken")
 	ErrIncorrectPassword   = errors.New("incorrect password")
 	ErrUsedShortenedString = errors.New("used shortened string")
+	ErrBatchAborted        = errors.New("batch aborted")
+	ErrShortCodesExhausted = errors.New("no unused generated shortened string")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrUsedAPIKeyPrefix    = errors.New("used api key prefix")
+	ErrInvalidAPIKey       = errors.New("invalid api key")
 )
@@ -0,0 +1,11 @@
+package port
+
+import "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+
+//go:generate mockgen -package mockups -destination mockups/mock_clickrecorder.go . ClickRecorder
+
+type ClickRecorder interface {
+	// Record queues click to be saved without blocking the caller; clientIP
+	// is hashed into click.IPHash and never saved in clear
+	Record(click *domain.Click, clientIP string)
+}
@@ -1,7 +1,13 @@
 package port
 
-//go:generate mockgen -package mockups -destination mockups/mock_generator.go . RandomStringGenerator
+import "context"
 
-type RandomStringGenerator interface {
-	RandomString() string
+//go:generate mockgen -package mockups -destination mockups/mock_generator.go . ShortCodeGenerator
+
+type ShortCodeGenerator interface {
+	// Generate returns a short code for a new link. extraLength asks for a
+	// code that many characters longer than usual, it's increased as codes
+	// keep colliding with existing links and generators never producing
+	// colliding codes may ignore it
+	Generate(ctx context.Context, extraLength int) (string, error)
 }
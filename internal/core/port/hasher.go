@@ -0,0 +1,13 @@
+package port
+
+//go:generate mockgen -package mockups -destination mockups/mock_hasher.go . PasswordHasher
+
+type PasswordHasher interface {
+	// Hash returns the encoded hash of password
+	Hash(password string) (string, error)
+	// Compare returns domain_errors.ErrIncorrectPassword if password don't match hash
+	Compare(hash string, password string) error
+	// NeedsRehash reports whether hash is a legacy plain text password or is
+	// encoded with other algorithm or parameters than the hasher's
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+)
+
+//go:generate mockgen -package mockups -destination mockups/mock_linkcache.go . LinkCache
+
+type LinkCache interface {
+	// Get returns found false on a miss. a found nil link is a cached lookup
+	// of a link which doesn't exist
+	Get(
+		ctx context.Context,
+		shortenedString string,
+	) (link *domain.Link, found bool, err error)
+	// Set caches link, or its absence if nil, for ttl. the cache owns link
+	Set(
+		ctx context.Context,
+		shortenedString string,
+		link *domain.Link,
+		ttl time.Duration,
+	) error
+	Delete(ctx context.Context, shortenedString string) error
+}
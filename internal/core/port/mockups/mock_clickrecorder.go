@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aria3ppp/url-shortener-openapi/internal/core/port (interfaces: ClickRecorder)
+
+// Package mockups is a generated GoMock package.
+package mockups
+
+import (
+	reflect "reflect"
+
+	domain "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClickRecorder is a mock of ClickRecorder interface.
+type MockClickRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickRecorderMockRecorder
+}
+
+// MockClickRecorderMockRecorder is the mock recorder for MockClickRecorder.
+type MockClickRecorderMockRecorder struct {
+	mock *MockClickRecorder
+}
+
+// NewMockClickRecorder creates a new mock instance.
+func NewMockClickRecorder(ctrl *gomock.Controller) *MockClickRecorder {
+	mock := &MockClickRecorder{ctrl: ctrl}
+	mock.recorder = &MockClickRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickRecorder) EXPECT() *MockClickRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockClickRecorder) Record(arg0 *domain.Click, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", arg0, arg1)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockClickRecorderMockRecorder) Record(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockClickRecorder)(nil).Record), arg0, arg1)
+}
@@ -1,48 +1,50 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aria3ppp/url-shortener-openapi/internal/core/port (interfaces: RandomStringGenerator)
+// Source: github.com/aria3ppp/url-shortener-openapi/internal/core/port (interfaces: ShortCodeGenerator)
 
 // Package mockups is a generated GoMock package.
 package mockups
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockRandomStringGenerator is a mock of RandomStringGenerator interface.
-type MockRandomStringGenerator struct {
+// MockShortCodeGenerator is a mock of ShortCodeGenerator interface.
+type MockShortCodeGenerator struct {
 	ctrl     *gomock.Controller
-	recorder *MockRandomStringGeneratorMockRecorder
+	recorder *MockShortCodeGeneratorMockRecorder
 }
 
-// MockRandomStringGeneratorMockRecorder is the mock recorder for MockRandomStringGenerator.
-type MockRandomStringGeneratorMockRecorder struct {
-	mock *MockRandomStringGenerator
+// MockShortCodeGeneratorMockRecorder is the mock recorder for MockShortCodeGenerator.
+type MockShortCodeGeneratorMockRecorder struct {
+	mock *MockShortCodeGenerator
 }
 
-// NewMockRandomStringGenerator creates a new mock instance.
-func NewMockRandomStringGenerator(ctrl *gomock.Controller) *MockRandomStringGenerator {
-	mock := &MockRandomStringGenerator{ctrl: ctrl}
-	mock.recorder = &MockRandomStringGeneratorMockRecorder{mock}
+// NewMockShortCodeGenerator creates a new mock instance.
+func NewMockShortCodeGenerator(ctrl *gomock.Controller) *MockShortCodeGenerator {
+	mock := &MockShortCodeGenerator{ctrl: ctrl}
+	mock.recorder = &MockShortCodeGeneratorMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRandomStringGenerator) EXPECT() *MockRandomStringGeneratorMockRecorder {
+func (m *MockShortCodeGenerator) EXPECT() *MockShortCodeGeneratorMockRecorder {
 	return m.recorder
 }
 
-// RandomString mocks base method.
-func (m *MockRandomStringGenerator) RandomString() string {
+// Generate mocks base method.
+func (m *MockShortCodeGenerator) Generate(arg0 context.Context, arg1 int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RandomString")
+	ret := m.ctrl.Call(m, "Generate", arg0, arg1)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RandomString indicates an expected call of RandomString.
-func (mr *MockRandomStringGeneratorMockRecorder) RandomString() *gomock.Call {
+// Generate indicates an expected call of Generate.
+func (mr *MockShortCodeGeneratorMockRecorder) Generate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomString", reflect.TypeOf((*MockRandomStringGenerator)(nil).RandomString))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockShortCodeGenerator)(nil).Generate), arg0, arg1)
 }
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aria3ppp/url-shortener-openapi/internal/core/port (interfaces: LinkCache)
+
+// Package mockups is a generated GoMock package.
+package mockups
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLinkCache is a mock of LinkCache interface.
+type MockLinkCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkCacheMockRecorder
+}
+
+// MockLinkCacheMockRecorder is the mock recorder for MockLinkCache.
+type MockLinkCacheMockRecorder struct {
+	mock *MockLinkCache
+}
+
+// NewMockLinkCache creates a new mock instance.
+func NewMockLinkCache(ctrl *gomock.Controller) *MockLinkCache {
+	mock := &MockLinkCache{ctrl: ctrl}
+	mock.recorder = &MockLinkCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkCache) EXPECT() *MockLinkCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLinkCache) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLinkCacheMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkCache)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockLinkCache) Get(arg0 context.Context, arg1 string) (*domain.Link, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLinkCacheMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLinkCache)(nil).Get), arg0, arg1)
+}
+
+// Set mocks base method.
+func (m *MockLinkCache) Set(arg0 context.Context, arg1 string, arg2 *domain.Link, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockLinkCacheMockRecorder) Set(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockLinkCache)(nil).Set), arg0, arg1, arg2, arg3)
+}
@@ -5,7 +5,9 @@
 package mockups
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -34,60 +36,339 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountClicks mocks base method.
+func (m *MockRepository) CountClicks(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClicks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountClicks indicates an expected call of CountClicks.
+func (mr *MockRepositoryMockRecorder) CountClicks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClicks", reflect.TypeOf((*MockRepository)(nil).CountClicks), arg0, arg1, arg2, arg3)
+}
+
+// CountUserLinks mocks base method.
+func (m *MockRepository) CountUserLinks(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserLinks", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserLinks indicates an expected call of CountUserLinks.
+func (mr *MockRepositoryMockRecorder) CountUserLinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserLinks", reflect.TypeOf((*MockRepository)(nil).CountUserLinks), arg0, arg1)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockRepository) CreateAPIKey(arg0 context.Context, arg1 *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockRepositoryMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockRepository)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// CreateClicks mocks base method.
+func (m *MockRepository) CreateClicks(arg0 context.Context, arg1 []*domain.Click) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClicks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateClicks indicates an expected call of CreateClicks.
+func (mr *MockRepositoryMockRecorder) CreateClicks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClicks", reflect.TypeOf((*MockRepository)(nil).CreateClicks), arg0, arg1)
+}
+
 // CreateLink mocks base method.
-func (m *MockRepository) CreateLink(arg0 *domain.Link) error {
+func (m *MockRepository) CreateLink(arg0 context.Context, arg1 *domain.Link) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateLink", arg0)
+	ret := m.ctrl.Call(m, "CreateLink", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateLink indicates an expected call of CreateLink.
-func (mr *MockRepositoryMockRecorder) CreateLink(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockRepository)(nil).CreateLink), arg0, arg1)
+}
+
+// CreateLinks mocks base method.
+func (m *MockRepository) CreateLinks(arg0 context.Context, arg1 []*domain.Link) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLinks", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLinks indicates an expected call of CreateLinks.
+func (mr *MockRepositoryMockRecorder) CreateLinks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockRepository)(nil).CreateLink), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLinks", reflect.TypeOf((*MockRepository)(nil).CreateLinks), arg0, arg1)
 }
 
 // CreateUser mocks base method.
-func (m *MockRepository) CreateUser(arg0 *domain.User) error {
+func (m *MockRepository) CreateUser(arg0 context.Context, arg1 *domain.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockRepositoryMockRecorder) CreateUser(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepository)(nil).CreateUser), arg0, arg1)
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockRepository) DeleteAPIKey(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockRepositoryMockRecorder) DeleteAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockRepository)(nil).DeleteAPIKey), arg0, arg1, arg2)
+}
+
+// DeleteLink mocks base method.
+func (m *MockRepository) DeleteLink(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLink", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLink indicates an expected call of DeleteLink.
+func (mr *MockRepositoryMockRecorder) DeleteLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockRepository)(nil).DeleteLink), arg0, arg1)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockRepository) GetAPIKey(arg0 context.Context, arg1 string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockRepositoryMockRecorder) GetAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockRepository)(nil).GetAPIKey), arg0, arg1)
 }
 
 // GetLink mocks base method.
-func (m *MockRepository) GetLink(arg0 string) (*domain.Link, error) {
+func (m *MockRepository) GetLink(arg0 context.Context, arg1 string) (*domain.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLink", arg0)
+	ret := m.ctrl.Call(m, "GetLink", arg0, arg1)
 	ret0, _ := ret[0].(*domain.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLink indicates an expected call of GetLink.
-func (mr *MockRepositoryMockRecorder) GetLink(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetLink(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockRepository)(nil).GetLink), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockRepository)(nil).GetLink), arg0, arg1)
 }
 
 // GetUser mocks base method.
-func (m *MockRepository) GetUser(arg0 string) (*domain.User, error) {
+func (m *MockRepository) GetUser(arg0 context.Context, arg1 string) (*domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0)
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
 	ret0, _ := ret[0].(*domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUser indicates an expected call of GetUser.
-func (mr *MockRepositoryMockRecorder) GetUser(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepository)(nil).GetUser), arg0, arg1)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockRepository) ListAPIKeys(arg0 context.Context, arg1 string) ([]*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockRepositoryMockRecorder) ListAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockRepository)(nil).ListAPIKeys), arg0, arg1)
+}
+
+// ListClickBuckets mocks base method.
+func (m *MockRepository) ListClickBuckets(arg0 context.Context, arg1 string, arg2 domain.StatsInterval, arg3, arg4 time.Time) ([]*domain.StatsBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClickBuckets", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.StatsBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClickBuckets indicates an expected call of ListClickBuckets.
+func (mr *MockRepositoryMockRecorder) ListClickBuckets(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClickBuckets", reflect.TypeOf((*MockRepository)(nil).ListClickBuckets), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListTopReferrers mocks base method.
+func (m *MockRepository) ListTopReferrers(arg0 context.Context, arg1 string, arg2, arg3 time.Time, arg4 int) ([]*domain.StatsCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTopReferrers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.StatsCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTopReferrers indicates an expected call of ListTopReferrers.
+func (mr *MockRepositoryMockRecorder) ListTopReferrers(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopReferrers", reflect.TypeOf((*MockRepository)(nil).ListTopReferrers), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListTopUserAgentFamilies mocks base method.
+func (m *MockRepository) ListTopUserAgentFamilies(arg0 context.Context, arg1 string, arg2, arg3 time.Time, arg4 int) ([]*domain.StatsCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTopUserAgentFamilies", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.StatsCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTopUserAgentFamilies indicates an expected call of ListTopUserAgentFamilies.
+func (mr *MockRepositoryMockRecorder) ListTopUserAgentFamilies(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopUserAgentFamilies", reflect.TypeOf((*MockRepository)(nil).ListTopUserAgentFamilies), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListUserLinks mocks base method.
+func (m *MockRepository) ListUserLinks(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLinks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLinks indicates an expected call of ListUserLinks.
+func (mr *MockRepositoryMockRecorder) ListUserLinks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLinks", reflect.TypeOf((*MockRepository)(nil).ListUserLinks), arg0, arg1, arg2, arg3)
+}
+
+// ListUserLinksAfter mocks base method.
+func (m *MockRepository) ListUserLinksAfter(arg0 context.Context, arg1 string, arg2 *domain.Link, arg3 int) ([]*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLinksAfter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLinksAfter indicates an expected call of ListUserLinksAfter.
+func (mr *MockRepositoryMockRecorder) ListUserLinksAfter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLinksAfter", reflect.TypeOf((*MockRepository)(nil).ListUserLinksAfter), arg0, arg1, arg2, arg3)
+}
+
+// NextIDBlock mocks base method.
+func (m *MockRepository) NextIDBlock(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextIDBlock", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextIDBlock indicates an expected call of NextIDBlock.
+func (mr *MockRepositoryMockRecorder) NextIDBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextIDBlock", reflect.TypeOf((*MockRepository)(nil).NextIDBlock), arg0)
+}
+
+// RegisterLinkClick mocks base method.
+func (m *MockRepository) RegisterLinkClick(arg0 context.Context, arg1 string, arg2 time.Time) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterLinkClick", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterLinkClick indicates an expected call of RegisterLinkClick.
+func (mr *MockRepositoryMockRecorder) RegisterLinkClick(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterLinkClick", reflect.TypeOf((*MockRepository)(nil).RegisterLinkClick), arg0, arg1, arg2)
+}
+
+// UpdateAPIKeyLastUsedAt mocks base method.
+func (m *MockRepository) UpdateAPIKeyLastUsedAt(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsedAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsedAt indicates an expected call of UpdateAPIKeyLastUsedAt.
+func (mr *MockRepositoryMockRecorder) UpdateAPIKeyLastUsedAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsedAt", reflect.TypeOf((*MockRepository)(nil).UpdateAPIKeyLastUsedAt), arg0, arg1, arg2)
+}
+
+// UpdateLink mocks base method.
+func (m *MockRepository) UpdateLink(arg0 context.Context, arg1 *domain.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLink", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLink indicates an expected call of UpdateLink.
+func (mr *MockRepositoryMockRecorder) UpdateLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLink", reflect.TypeOf((*MockRepository)(nil).UpdateLink), arg0, arg1)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockRepository) UpdateUserPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockRepositoryMockRecorder) UpdateUserPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepository)(nil).GetUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockRepository)(nil).UpdateUserPassword), arg0, arg1, arg2)
 }
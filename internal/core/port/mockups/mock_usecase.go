@@ -0,0 +1,275 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aria3ppp/url-shortener-openapi/internal/core/port (interfaces: ServiceUseCases)
+
+// Package mockups is a generated GoMock package.
+package mockups
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockServiceUseCases is a mock of ServiceUseCases interface.
+type MockServiceUseCases struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceUseCasesMockRecorder
+}
+
+// MockServiceUseCasesMockRecorder is the mock recorder for MockServiceUseCases.
+type MockServiceUseCasesMockRecorder struct {
+	mock *MockServiceUseCases
+}
+
+// NewMockServiceUseCases creates a new mock instance.
+func NewMockServiceUseCases(ctrl *gomock.Controller) *MockServiceUseCases {
+	mock := &MockServiceUseCases{ctrl: ctrl}
+	mock.recorder = &MockServiceUseCasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceUseCases) EXPECT() *MockServiceUseCasesMockRecorder {
+	return m.recorder
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockServiceUseCases) AuthenticateAPIKey(arg0 context.Context, arg1 string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockServiceUseCasesMockRecorder) AuthenticateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockServiceUseCases)(nil).AuthenticateAPIKey), arg0, arg1)
+}
+
+// AuthenticateUser mocks base method.
+func (m *MockServiceUseCases) AuthenticateUser(arg0 context.Context, arg1, arg2 string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateUser indicates an expected call of AuthenticateUser.
+func (mr *MockServiceUseCasesMockRecorder) AuthenticateUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateUser", reflect.TypeOf((*MockServiceUseCases)(nil).AuthenticateUser), arg0, arg1, arg2)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockServiceUseCases) CreateAPIKey(arg0 context.Context, arg1 *domain.User, arg2 string, arg3 *time.Time) (*domain.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockServiceUseCasesMockRecorder) CreateAPIKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockServiceUseCases)(nil).CreateAPIKey), arg0, arg1, arg2, arg3)
+}
+
+// CreateLink mocks base method.
+func (m *MockServiceUseCases) CreateLink(arg0 context.Context, arg1 *domain.Link, arg2 *domain.User) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLink indicates an expected call of CreateLink.
+func (mr *MockServiceUseCasesMockRecorder) CreateLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockServiceUseCases)(nil).CreateLink), arg0, arg1, arg2)
+}
+
+// CreateLinks mocks base method.
+func (m *MockServiceUseCases) CreateLinks(arg0 context.Context, arg1 []*domain.Link, arg2 *domain.User, arg3 bool) ([]*domain.LinkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLinks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.LinkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLinks indicates an expected call of CreateLinks.
+func (mr *MockServiceUseCasesMockRecorder) CreateLinks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLinks", reflect.TypeOf((*MockServiceUseCases)(nil).CreateLinks), arg0, arg1, arg2, arg3)
+}
+
+// CreateUser mocks base method.
+func (m *MockServiceUseCases) CreateUser(arg0 context.Context, arg1 *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockServiceUseCasesMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockServiceUseCases)(nil).CreateUser), arg0, arg1)
+}
+
+// DeleteLink mocks base method.
+func (m *MockServiceUseCases) DeleteLink(arg0 context.Context, arg1 *domain.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLink indicates an expected call of DeleteLink.
+func (mr *MockServiceUseCasesMockRecorder) DeleteLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockServiceUseCases)(nil).DeleteLink), arg0, arg1, arg2)
+}
+
+// ExportLinks mocks base method.
+func (m *MockServiceUseCases) ExportLinks(arg0 context.Context, arg1 *domain.User, arg2 func(*domain.Link) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportLinks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportLinks indicates an expected call of ExportLinks.
+func (mr *MockServiceUseCasesMockRecorder) ExportLinks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportLinks", reflect.TypeOf((*MockServiceUseCases)(nil).ExportLinks), arg0, arg1, arg2)
+}
+
+// GetLink mocks base method.
+func (m *MockServiceUseCases) GetLink(arg0 context.Context, arg1, arg2 string) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLink indicates an expected call of GetLink.
+func (mr *MockServiceUseCasesMockRecorder) GetLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockServiceUseCases)(nil).GetLink), arg0, arg1, arg2)
+}
+
+// GetLinkStats mocks base method.
+func (m *MockServiceUseCases) GetLinkStats(arg0 context.Context, arg1 *domain.User, arg2 string, arg3 domain.StatsInterval, arg4, arg5 time.Time) (*domain.LinkStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkStats", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*domain.LinkStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkStats indicates an expected call of GetLinkStats.
+func (mr *MockServiceUseCasesMockRecorder) GetLinkStats(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkStats", reflect.TypeOf((*MockServiceUseCases)(nil).GetLinkStats), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// GetLinkUser mocks base method.
+func (m *MockServiceUseCases) GetLinkUser(arg0 context.Context, arg1 string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkUser", arg0, arg1)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkUser indicates an expected call of GetLinkUser.
+func (mr *MockServiceUseCasesMockRecorder) GetLinkUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkUser", reflect.TypeOf((*MockServiceUseCases)(nil).GetLinkUser), arg0, arg1)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockServiceUseCases) ListAPIKeys(arg0 context.Context, arg1 *domain.User) ([]*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockServiceUseCasesMockRecorder) ListAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockServiceUseCases)(nil).ListAPIKeys), arg0, arg1)
+}
+
+// ListLinks mocks base method.
+func (m *MockServiceUseCases) ListLinks(arg0 context.Context, arg1 *domain.User, arg2, arg3 int) ([]*domain.Link, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.Link)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLinks indicates an expected call of ListLinks.
+func (mr *MockServiceUseCasesMockRecorder) ListLinks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinks", reflect.TypeOf((*MockServiceUseCases)(nil).ListLinks), arg0, arg1, arg2, arg3)
+}
+
+// PreviewLink mocks base method.
+func (m *MockServiceUseCases) PreviewLink(arg0 context.Context, arg1 string) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewLink", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewLink indicates an expected call of PreviewLink.
+func (mr *MockServiceUseCasesMockRecorder) PreviewLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewLink", reflect.TypeOf((*MockServiceUseCases)(nil).PreviewLink), arg0, arg1)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockServiceUseCases) RevokeAPIKey(arg0 context.Context, arg1 *domain.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockServiceUseCasesMockRecorder) RevokeAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockServiceUseCases)(nil).RevokeAPIKey), arg0, arg1, arg2)
+}
+
+// UpdateLink mocks base method.
+func (m *MockServiceUseCases) UpdateLink(arg0 context.Context, arg1 *domain.User, arg2, arg3 string, arg4 *domain.RedirectType) (*domain.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLink", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLink indicates an expected call of UpdateLink.
+func (mr *MockServiceUseCasesMockRecorder) UpdateLink(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLink", reflect.TypeOf((*MockServiceUseCases)(nil).UpdateLink), arg0, arg1, arg2, arg3, arg4)
+}
@@ -1,14 +1,100 @@
 package port
 
-import "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+)
 
 //go:generate mockgen -package mockups -destination mockups/mock_repository.go . Repository
 
 type Repository interface {
 	// link
-	GetLink(shortenedString string) (*domain.Link, error)
-	CreateLink(link *domain.Link) error
+	GetLink(ctx context.Context, shortenedString string) (*domain.Link, error)
+	// RegisterLinkClick atomically increments the click count of a link
+	// which is not expired at now, otherwise returns
+	// domain_errors.ErrLinkExpired
+	RegisterLinkClick(
+		ctx context.Context,
+		shortenedString string,
+		now time.Time,
+	) (*domain.Link, error)
+	CreateLink(ctx context.Context, link *domain.Link) error
+	// CreateLinks creates links in a single transaction. if any shortened
+	// string is used, by another link or one before it in links, nothing is
+	// created and the indexes of the used ones are returned
+	CreateLinks(ctx context.Context, links []*domain.Link) (used []int, err error)
+	UpdateLink(ctx context.Context, link *domain.Link) error
+	DeleteLink(ctx context.Context, shortenedString string) error
+	ListUserLinks(
+		ctx context.Context,
+		username string,
+		offset int,
+		limit int,
+	) ([]*domain.Link, error)
+	// ListUserLinksAfter lists links in the order of ListUserLinks from the
+	// one after the given link, which is the last of the previous page, or
+	// from the first if after is nil. unlike offsets, deep pages are read as
+	// fast as the first
+	ListUserLinksAfter(
+		ctx context.Context,
+		username string,
+		after *domain.Link,
+		limit int,
+	) ([]*domain.Link, error)
+	CountUserLinks(ctx context.Context, username string) (int, error)
 	// user
-	GetUser(username string) (*domain.User, error)
-	CreateUser(user *domain.User) error
+	GetUser(ctx context.Context, username string) (*domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User) error
+	UpdateUserPassword(ctx context.Context, username string, password string) error
+	// api key
+	GetAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error)
+	ListAPIKeys(ctx context.Context, username string) ([]*domain.APIKey, error)
+	CreateAPIKey(ctx context.Context, apiKey *domain.APIKey) error
+	DeleteAPIKey(ctx context.Context, username string, prefix string) error
+	UpdateAPIKeyLastUsedAt(
+		ctx context.Context,
+		prefix string,
+		lastUsedAt time.Time,
+	) error
+	// short code
+	// NextIDBlock returns a new number on every call, even across processes
+	// sharing the storage, so each caller can own a block of ids
+	NextIDBlock(ctx context.Context) (int64, error)
+	// click
+	// CreateClicks saves clicks in a single transaction, clicks of links
+	// deleted in the meantime are dropped. clicks not domain.Click.Counted
+	// are added to the click counts of their links
+	CreateClicks(ctx context.Context, clicks []*domain.Click) error
+	// click queries are bounded to clicks in [from, to)
+	CountClicks(
+		ctx context.Context,
+		shortenedString string,
+		from time.Time,
+		to time.Time,
+	) (clicks int, uniqueVisitors int, err error)
+	// ListClickBuckets returns the buckets having clicks ordered by start
+	ListClickBuckets(
+		ctx context.Context,
+		shortenedString string,
+		interval domain.StatsInterval,
+		from time.Time,
+		to time.Time,
+	) ([]*domain.StatsBucket, error)
+	// ListTopReferrers skips clicks without a referrer
+	ListTopReferrers(
+		ctx context.Context,
+		shortenedString string,
+		from time.Time,
+		to time.Time,
+		limit int,
+	) ([]*domain.StatsCount, error)
+	ListTopUserAgentFamilies(
+		ctx context.Context,
+		shortenedString string,
+		from time.Time,
+		to time.Time,
+		limit int,
+	) ([]*domain.StatsCount, error)
 }
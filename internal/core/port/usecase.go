@@ -1,16 +1,97 @@
 package port
 
-import "github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+)
+
+//go:generate mockgen -package mockups -destination mockups/mock_usecase.go . ServiceUseCases
 
 type ServiceUseCases interface {
 	// link usecases
-	GetLink(shortenedString string) (*domain.Link, error)
-	CreateLink(
-		url string,
+	// GetLink resolves a link to follow. the click is counted at once if the
+	// link is domain.Link.ClickLimited, else it's up to the caller to save
+	// it uncounted (see CreateClicks of Repository). it returns
+	// domain_errors.ErrLinkLocked if the link is protected and password is
+	// empty, and domain_errors.ErrIncorrectPassword if password don't match
+	GetLink(
+		ctx context.Context,
 		shortenedString string,
+		password string,
+	) (*domain.Link, error)
+	// PreviewLink resolves a link like GetLink without counting a click or
+	// checking its password
+	PreviewLink(ctx context.Context, shortenedString string) (*domain.Link, error)
+	// CreateLink creates a link owned by an authenticated user. a random
+	// shortened string is generated if link.ShortenedString is empty, and
+	// link.Password is hashed if not empty
+	CreateLink(
+		ctx context.Context,
+		link *domain.Link,
+		user *domain.User,
+	) (*domain.Link, error)
+	// CreateLinks creates links like CreateLink with a result for each in
+	// the same order. if atomic either every link is created or none, and
+	// links not failing themselves get domain_errors.ErrBatchAborted
+	CreateLinks(
+		ctx context.Context,
+		links []*domain.Link,
 		user *domain.User,
+		atomic bool,
+	) ([]*domain.LinkResult, error)
+	// UpdateLink, DeleteLink return domain_errors.ErrLinkNotOwned if the link
+	// is not owned by user. the redirect type is kept if redirectType is nil
+	// and reset to the server default if it's domain.RedirectDefault
+	UpdateLink(
+		ctx context.Context,
+		user *domain.User,
+		shortenedString string,
+		url string,
+		redirectType *domain.RedirectType,
 	) (*domain.Link, error)
+	DeleteLink(ctx context.Context, user *domain.User, shortenedString string) error
+	ListLinks(
+		ctx context.Context,
+		user *domain.User,
+		page int,
+		perPage int,
+	) (links []*domain.Link, total int, err error)
+	// ExportLinks calls write for every link owned by user, newest first. links
+	// are read a page at a time so they are never all in memory
+	ExportLinks(
+		ctx context.Context,
+		user *domain.User,
+		write func(link *domain.Link) error,
+	) error
+	// GetLinkStats aggregates clicks in [from, to); series has a bucket for
+	// every interval even if it has no clicks
+	GetLinkStats(
+		ctx context.Context,
+		user *domain.User,
+		shortenedString string,
+		interval domain.StatsInterval,
+		from time.Time,
+		to time.Time,
+	) (*domain.LinkStats, error)
 	// user usecases
-	GetLinkUser(shortenedString string) (*domain.User, error)
-	CreateUser(user *domain.User) error
+	GetLinkUser(ctx context.Context, shortenedString string) (*domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User) error
+	// authentication usecases
+	AuthenticateUser(
+		ctx context.Context,
+		username string,
+		password string,
+	) (*domain.User, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (*domain.User, error)
+	// api key usecases
+	CreateAPIKey(
+		ctx context.Context,
+		user *domain.User,
+		name string,
+		expiresAt *time.Time,
+	) (apiKey *domain.APIKey, key string, err error)
+	ListAPIKeys(ctx context.Context, user *domain.User) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, user *domain.User, prefix string) error
 }
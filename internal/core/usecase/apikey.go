@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+)
+
+// api keys are formatted as "usk_<prefix>_<secret>"
+const (
+	apiKeyTag          = "usk"
+	apiKeyPrefixLength = 8
+	apiKeySecretLength = 32
+	apiKeyAlphabet     = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// apiKeyMaxAttempts bounds the retries of prefixes which are already used
+const apiKeyMaxAttempts = 3
+
+func (s *serviceUseCases) CreateAPIKey(
+	ctx context.Context,
+	user *domain.User,
+	name string,
+	expiresAt *time.Time,
+) (*domain.APIKey, string, error) {
+	for attempt := 0; attempt < apiKeyMaxAttempts; attempt++ {
+		apiKey, key, err := s.createAPIKey(ctx, user, name, expiresAt)
+		if err == nil {
+			return apiKey, key, nil
+		}
+		if !errors.Is(err, domain_errors.ErrUsedAPIKeyPrefix) {
+			return nil, "", err
+		}
+	}
+
+	return nil, "", fmt.Errorf(
+		"usecase.CreateAPIKey: no unused api key prefix after %d attempts",
+		apiKeyMaxAttempts,
+	)
+}
+
+// createAPIKey creates an api key of a random prefix which may be used
+func (s *serviceUseCases) createAPIKey(
+	ctx context.Context,
+	user *domain.User,
+	name string,
+	expiresAt *time.Time,
+) (*domain.APIKey, string, error) {
+	prefix, err := randomAPIKeyString(apiKeyPrefixLength)
+	if err != nil {
+		return nil, "", fmt.Errorf(
+			"usecase.CreateAPIKey: could not generate prefix: %w", err)
+	}
+	secret, err := randomAPIKeyString(apiKeySecretLength)
+	if err != nil {
+		return nil, "", fmt.Errorf(
+			"usecase.CreateAPIKey: could not generate secret: %w", err)
+	}
+	key := apiKeyTag + "_" + prefix + "_" + secret
+
+	apiKey := &domain.APIKey{
+		Prefix:    prefix,
+		Hash:      hashAPIKey(key),
+		Name:      name,
+		Username:  user.Username,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		ExpiresAt: expiresAt,
+	}
+	err = s.repo.CreateAPIKey(ctx, apiKey)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrUsedAPIKeyPrefix) {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf(
+			"usecase.CreateAPIKey: repository.CreateAPIKey unhandled error: %w",
+			err,
+		)
+	}
+
+	return apiKey, key, nil
+}
+
+func (s *serviceUseCases) ListAPIKeys(
+	ctx context.Context,
+	user *domain.User,
+) ([]*domain.APIKey, error) {
+	apiKeys, err := s.repo.ListAPIKeys(ctx, user.Username)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"usecase.ListAPIKeys: repository.ListAPIKeys unhandled error: %w",
+			err,
+		)
+	}
+	return apiKeys, nil
+}
+
+func (s *serviceUseCases) RevokeAPIKey(
+	ctx context.Context,
+	user *domain.User,
+	prefix string,
+) error {
+	err := s.repo.DeleteAPIKey(ctx, user.Username, prefix)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrAPIKeyNotFound) {
+			return fmt.Errorf(
+				"usecase.RevokeAPIKey: api key don't exists: %w", err)
+		}
+		return fmt.Errorf(
+			"usecase.RevokeAPIKey: repository.DeleteAPIKey unhandled error: %w",
+			err,
+		)
+	}
+	return nil
+}
+
+// parseAPIKey returns the lookup prefix of a well formed api key
+func parseAPIKey(key string) (prefix string, ok bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 ||
+		parts[0] != apiKeyTag ||
+		len(parts[1]) != apiKeyPrefixLength ||
+		len(parts[2]) != apiKeySecretLength {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func randomAPIKeyString(length int) (string, error) {
+	max := big.NewInt(int64(len(apiKeyAlphabet)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = apiKeyAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,245 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var apiKeyRegexp = regexp.MustCompile("^usk_([a-zA-Z0-9]{8})_[a-zA-Z0-9]{32}$")
+
+func TestCreateAPIKey(t *testing.T) {
+	require := require.New(t)
+
+	controller := gomock.NewController(t)
+	m := mocks{
+		repository: mockups.NewMockRepository(controller),
+		generator:  mockups.NewMockShortCodeGenerator(controller),
+		hasher:     mockups.NewMockPasswordHasher(controller),
+	}
+	service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+	user := &domain.User{Username: "username"}
+	expiresAt := time.Now().Add(time.Hour)
+
+	// CreateAPIKey unhandled error
+	m.repository.EXPECT().
+		CreateAPIKey(ctx, gomock.Any()).
+		Return(errors.New("CreateAPIKey_unhandled_error"))
+
+	apiKey, key, err := service.CreateAPIKey(ctx, user, "name", &expiresAt)
+	require.Equal(
+		fmt.Errorf(
+			"usecase.CreateAPIKey: repository.CreateAPIKey unhandled error: %w",
+			errors.New("CreateAPIKey_unhandled_error"),
+		),
+		err,
+	)
+	require.Nil(apiKey)
+	require.Empty(key)
+
+	// used prefixes exhaust the attempts
+	m.repository.EXPECT().
+		CreateAPIKey(ctx, gomock.Any()).
+		Return(domain_errors.ErrUsedAPIKeyPrefix).
+		Times(3)
+
+	apiKey, key, err = service.CreateAPIKey(ctx, user, "name", &expiresAt)
+	require.Equal(
+		errors.New("usecase.CreateAPIKey: no unused api key prefix after 3 attempts"),
+		err,
+	)
+	require.Nil(apiKey)
+	require.Empty(key)
+
+	// ok after a used prefix
+	var usedPrefix string
+	m.repository.EXPECT().
+		CreateAPIKey(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, apiKey *domain.APIKey) error {
+			usedPrefix = apiKey.Prefix
+			return domain_errors.ErrUsedAPIKeyPrefix
+		})
+	var createdAPIKey *domain.APIKey
+	m.repository.EXPECT().
+		CreateAPIKey(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, apiKey *domain.APIKey) error {
+			createdAPIKey = apiKey
+			return nil
+		})
+
+	apiKey, key, err = service.CreateAPIKey(ctx, user, "name", &expiresAt)
+	require.NoError(err)
+	require.Equal(createdAPIKey, apiKey)
+	require.NotEqual(usedPrefix, apiKey.Prefix)
+
+	// key is well formed and only its hash is saved
+	matches := apiKeyRegexp.FindStringSubmatch(key)
+	require.Len(matches, 2)
+	sum := sha256.Sum256([]byte(key))
+	require.Equal(matches[1], apiKey.Prefix)
+	require.Equal(hex.EncodeToString(sum[:]), apiKey.Hash)
+	require.Equal("name", apiKey.Name)
+	require.Equal("username", apiKey.Username)
+	require.Equal(&expiresAt, apiKey.ExpiresAt)
+	require.Nil(apiKey.LastUsedAt)
+	require.WithinDuration(time.Now(), apiKey.CreatedAt, time.Minute)
+}
+
+func TestListAPIKeys(t *testing.T) {
+	type want struct {
+		apiKeys []*domain.APIKey
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "ListAPIKeys unhandled error",
+			want: want{
+				apiKeys: nil,
+				err: fmt.Errorf(
+					"usecase.ListAPIKeys: repository.ListAPIKeys unhandled error: %w",
+					errors.New("ListAPIKeys_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					ListAPIKeys(ctx, "username").
+					Return(nil, errors.New("ListAPIKeys_unhandled_error"))
+			},
+		},
+		{
+			name: "ok",
+			want: want{
+				apiKeys: []*domain.APIKey{
+					{Prefix: "prefix", Name: "name", Username: "username"},
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					ListAPIKeys(ctx, "username").
+					Return(
+						[]*domain.APIKey{
+							{Prefix: "prefix", Name: "name", Username: "username"},
+						},
+						nil,
+					)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			apiKeys, err := service.ListAPIKeys(
+				ctx,
+				&domain.User{Username: "username"},
+			)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.apiKeys, apiKeys)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "api key not found",
+			want: want{
+				err: fmt.Errorf(
+					"usecase.RevokeAPIKey: api key don't exists: %w",
+					domain_errors.ErrAPIKeyNotFound,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					DeleteAPIKey(ctx, "username", "prefix").
+					Return(domain_errors.ErrAPIKeyNotFound)
+			},
+		},
+		{
+			name: "DeleteAPIKey unhandled error",
+			want: want{
+				err: fmt.Errorf(
+					"usecase.RevokeAPIKey: repository.DeleteAPIKey unhandled error: %w",
+					errors.New("DeleteAPIKey_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					DeleteAPIKey(ctx, "username", "prefix").
+					Return(errors.New("DeleteAPIKey_unhandled_error"))
+			},
+		},
+		{
+			name: "ok",
+			want: want{err: nil},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					DeleteAPIKey(ctx, "username", "prefix").
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			err := service.RevokeAPIKey(
+				ctx,
+				&domain.User{Username: "username"},
+				"prefix",
+			)
+
+			require.Equal(tt.want.err, err)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+)
+
+func (s *serviceUseCases) AuthenticateUser(
+	ctx context.Context,
+	username string,
+	password string,
+) (*domain.User, error) {
+	// check user exists
+	repoUser, err := s.repo.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrUserNotFound) {
+			// take as long as a known user would, so usernames can't be
+			// told apart by the response time
+			_ = s.hasher.Compare(s.getDummyHash(), password)
+			return nil, fmt.Errorf(
+				"usecase.AuthenticateUser: user don't exists: %w", err)
+		}
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateUser: repository.GetUser unhandled error: %w",
+			err,
+		)
+	}
+
+	// check the user password
+	err = s.hasher.Compare(repoUser.Password, password)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrIncorrectPassword) {
+			return nil, fmt.Errorf(
+				"usecase.AuthenticateUser: user password don't match: %w", err)
+		}
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateUser: hasher.Compare unhandled error: %w", err)
+	}
+
+	// upgrade plain text or outdated password hash
+	if s.hasher.NeedsRehash(repoUser.Password) {
+		hash, err := s.hasher.Hash(password)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"usecase.AuthenticateUser: hasher.Hash unhandled error: %w", err)
+		}
+		err = s.repo.UpdateUserPassword(ctx, repoUser.Username, hash)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"usecase.AuthenticateUser: repository.UpdateUserPassword unhandled error: %w",
+				err,
+			)
+		}
+	}
+
+	// omit password from serialization
+	return &domain.User{Username: repoUser.Username}, nil
+}
+
+// getDummyHash returns a hash of the configured hasher matching no password,
+// it's hashed once on the first unknown user
+func (s *serviceUseCases) getDummyHash() string {
+	s.dummyHashOnce.Do(func() {
+		hash, err := s.hasher.Hash("dummy password of unknown users")
+		if err != nil {
+			// unknown users are only told apart while the hasher fails
+			return
+		}
+		s.dummyHash = hash
+	})
+	return s.dummyHash
+}
+
+func (s *serviceUseCases) AuthenticateAPIKey(
+	ctx context.Context,
+	key string,
+) (*domain.User, error) {
+	prefix, ok := parseAPIKey(key)
+	if !ok {
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateAPIKey: malformed api key: %w",
+			domain_errors.ErrInvalidAPIKey,
+		)
+	}
+
+	apiKey, err := s.repo.GetAPIKey(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrAPIKeyNotFound) {
+			return nil, fmt.Errorf(
+				"usecase.AuthenticateAPIKey: api key don't exists: %w",
+				domain_errors.ErrInvalidAPIKey,
+			)
+		}
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateAPIKey: repository.GetAPIKey unhandled error: %w",
+			err,
+		)
+	}
+
+	// compare key hashes in constant time
+	if subtle.ConstantTimeCompare(
+		[]byte(hashAPIKey(key)),
+		[]byte(apiKey.Hash),
+	) != 1 {
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateAPIKey: api key don't match: %w",
+			domain_errors.ErrInvalidAPIKey,
+		)
+	}
+
+	now := time.Now()
+	if apiKey.Expired(now) {
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateAPIKey: api key expired: %w",
+			domain_errors.ErrInvalidAPIKey,
+		)
+	}
+
+	err = s.repo.UpdateAPIKeyLastUsedAt(ctx, apiKey.Prefix, now)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"usecase.AuthenticateAPIKey: repository.UpdateAPIKeyLastUsedAt unhandled error: %w",
+			err,
+		)
+	}
+
+	return &domain.User{Username: apiKey.Username}, nil
+}
+
+// hashAPIKey returns the hex encoded sha256 of key. api keys carry enough
+// entropy to make a slow password hash unnecessary
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,495 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateUser(t *testing.T) {
+	type args struct {
+		username string
+		password string
+	}
+	type want struct {
+		user *domain.User
+		err  error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "user not found",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateUser: user don't exists: %w",
+					domain_errors.ErrUserNotFound,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(nil, domain_errors.ErrUserNotFound)
+				// a dummy hash is compared as for a known user
+				m.hasher.EXPECT().
+					Hash(gomock.Any()).
+					Return("dummy_hash", nil)
+				m.hasher.EXPECT().
+					Compare("dummy_hash", "password").
+					Return(domain_errors.ErrIncorrectPassword)
+			},
+		},
+		{
+			name: "GetUser unhandled error",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateUser: repository.GetUser unhandled error: %w",
+					errors.New("GetUser_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(nil, errors.New("GetUser_unhandled_error"))
+			},
+		},
+		{
+			name: "incorrect password",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateUser: user password don't match: %w",
+					domain_errors.ErrIncorrectPassword,
+				),
+			},
+			mock: func(m mocks) {
+				getUserCall := m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(
+						&domain.User{
+							Username: "username",
+							Password: "unmatched_password_hash",
+						},
+						nil,
+					)
+
+				m.hasher.EXPECT().
+					Compare("unmatched_password_hash", "password").
+					Return(domain_errors.ErrIncorrectPassword).
+					After(getUserCall)
+			},
+		},
+		{
+			name: "Compare unhandled error",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateUser: hasher.Compare unhandled error: %w",
+					errors.New("Compare_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				getUserCall := m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(
+						&domain.User{
+							Username: "username",
+							Password: "password_hash",
+						},
+						nil,
+					)
+
+				m.hasher.EXPECT().
+					Compare("password_hash", "password").
+					Return(errors.New("Compare_unhandled_error")).
+					After(getUserCall)
+			},
+		},
+		{
+			name: "rehash Hash unhandled error",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateUser: hasher.Hash unhandled error: %w",
+					errors.New("Hash_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				getUserCall := m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(
+						&domain.User{
+							Username: "username",
+							Password: "password",
+						},
+						nil,
+					)
+
+				compareCall := m.hasher.EXPECT().
+					Compare("password", "password").
+					Return(nil).
+					After(getUserCall)
+
+				needsRehashCall := m.hasher.EXPECT().
+					NeedsRehash("password").
+					Return(true).
+					After(compareCall)
+
+				m.hasher.EXPECT().
+					Hash("password").
+					Return("", errors.New("Hash_unhandled_error")).
+					After(needsRehashCall)
+			},
+		},
+		{
+			name: "rehash UpdateUserPassword unhandled error",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateUser: repository.UpdateUserPassword unhandled error: %w",
+					errors.New("UpdateUserPassword_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				getUserCall := m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(
+						&domain.User{
+							Username: "username",
+							Password: "password",
+						},
+						nil,
+					)
+
+				compareCall := m.hasher.EXPECT().
+					Compare("password", "password").
+					Return(nil).
+					After(getUserCall)
+
+				needsRehashCall := m.hasher.EXPECT().
+					NeedsRehash("password").
+					Return(true).
+					After(compareCall)
+
+				hashCall := m.hasher.EXPECT().
+					Hash("password").
+					Return("password_hash", nil).
+					After(needsRehashCall)
+
+				m.repository.EXPECT().
+					UpdateUserPassword(ctx, "username", "password_hash").
+					Return(errors.New("UpdateUserPassword_unhandled_error")).
+					After(hashCall)
+			},
+		},
+		{
+			name: "ok upgrading plain text password",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: &domain.User{Username: "username"},
+				err:  nil,
+			},
+			mock: func(m mocks) {
+				getUserCall := m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(
+						&domain.User{
+							Username: "username",
+							Password: "password",
+						},
+						nil,
+					)
+
+				compareCall := m.hasher.EXPECT().
+					Compare("password", "password").
+					Return(nil).
+					After(getUserCall)
+
+				needsRehashCall := m.hasher.EXPECT().
+					NeedsRehash("password").
+					Return(true).
+					After(compareCall)
+
+				hashCall := m.hasher.EXPECT().
+					Hash("password").
+					Return("password_hash", nil).
+					After(needsRehashCall)
+
+				m.repository.EXPECT().
+					UpdateUserPassword(ctx, "username", "password_hash").
+					Return(nil).
+					After(hashCall)
+			},
+		},
+		{
+			name: "ok",
+			args: args{username: "username", password: "password"},
+			want: want{
+				user: &domain.User{
+					Username: "username",
+					Password: "", // omitted password from serialization
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				getUserCall := m.repository.EXPECT().
+					GetUser(ctx, "username").
+					Return(
+						&domain.User{
+							Username: "username",
+							Password: "password_hash",
+						},
+						nil,
+					)
+
+				compareCall := m.hasher.EXPECT().
+					Compare("password_hash", "password").
+					Return(nil).
+					After(getUserCall)
+
+				m.hasher.EXPECT().
+					NeedsRehash("password_hash").
+					Return(false).
+					After(compareCall)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			user, err := service.AuthenticateUser(
+				ctx,
+				tt.args.username,
+				tt.args.password,
+			)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.user, user)
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	type args struct {
+		key string
+	}
+	type want struct {
+		user *domain.User
+		err  error
+	}
+
+	const (
+		key = "usk_AbCd1234_0123456789abcdefghijABCDEFGHIJkl"
+		// sha256 of key
+		keyHash = "ce2e9f920f02b9a4af12283debc3fa95b2b2452282a7dd204723f82da34ab6b7"
+	)
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "malformed api key",
+			args: args{key: "malformed_api_key"},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateAPIKey: malformed api key: %w",
+					domain_errors.ErrInvalidAPIKey,
+				),
+			},
+			mock: func(m mocks) {},
+		},
+		{
+			name: "api key not found",
+			args: args{key: key},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateAPIKey: api key don't exists: %w",
+					domain_errors.ErrInvalidAPIKey,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetAPIKey(ctx, "AbCd1234").
+					Return(nil, domain_errors.ErrAPIKeyNotFound)
+			},
+		},
+		{
+			name: "GetAPIKey unhandled error",
+			args: args{key: key},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateAPIKey: repository.GetAPIKey unhandled error: %w",
+					errors.New("GetAPIKey_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetAPIKey(ctx, "AbCd1234").
+					Return(nil, errors.New("GetAPIKey_unhandled_error"))
+			},
+		},
+		{
+			name: "api key don't match",
+			args: args{key: key},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateAPIKey: api key don't match: %w",
+					domain_errors.ErrInvalidAPIKey,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetAPIKey(ctx, "AbCd1234").
+					Return(
+						&domain.APIKey{
+							Prefix:   "AbCd1234",
+							Hash:     "unmatched_hash",
+							Username: "username",
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "api key expired",
+			args: args{key: key},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateAPIKey: api key expired: %w",
+					domain_errors.ErrInvalidAPIKey,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetAPIKey(ctx, "AbCd1234").
+					Return(
+						&domain.APIKey{
+							Prefix:    "AbCd1234",
+							Hash:      keyHash,
+							Username:  "username",
+							ExpiresAt: &past,
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "UpdateAPIKeyLastUsedAt unhandled error",
+			args: args{key: key},
+			want: want{
+				user: nil,
+				err: fmt.Errorf(
+					"usecase.AuthenticateAPIKey: repository.UpdateAPIKeyLastUsedAt unhandled error: %w",
+					errors.New("UpdateAPIKeyLastUsedAt_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				getAPIKeyCall := m.repository.EXPECT().
+					GetAPIKey(ctx, "AbCd1234").
+					Return(
+						&domain.APIKey{
+							Prefix:   "AbCd1234",
+							Hash:     keyHash,
+							Username: "username",
+						},
+						nil,
+					)
+
+				m.repository.EXPECT().
+					UpdateAPIKeyLastUsedAt(ctx, "AbCd1234", gomock.Any()).
+					Return(errors.New("UpdateAPIKeyLastUsedAt_unhandled_error")).
+					After(getAPIKeyCall)
+			},
+		},
+		{
+			name: "ok",
+			args: args{key: key},
+			want: want{
+				user: &domain.User{Username: "username"},
+				err:  nil,
+			},
+			mock: func(m mocks) {
+				getAPIKeyCall := m.repository.EXPECT().
+					GetAPIKey(ctx, "AbCd1234").
+					Return(
+						&domain.APIKey{
+							Prefix:    "AbCd1234",
+							Hash:      keyHash,
+							Username:  "username",
+							ExpiresAt: &future,
+						},
+						nil,
+					)
+
+				m.repository.EXPECT().
+					UpdateAPIKeyLastUsedAt(ctx, "AbCd1234", gomock.Any()).
+					Return(nil).
+					After(getAPIKeyCall)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			user, err := service.AuthenticateAPIKey(ctx, tt.args.key)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.user, user)
+		})
+	}
+}
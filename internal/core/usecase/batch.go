@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+)
+
+func (s *serviceUseCases) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+	user *domain.User,
+	atomic bool,
+) ([]*domain.LinkResult, error) {
+	results := make([]*domain.LinkResult, len(links))
+
+	if !atomic {
+		// every link fails on its own
+		for i, link := range links {
+			created, err := s.CreateLink(ctx, link, user)
+			results[i] = &domain.LinkResult{Link: created, Err: err}
+		}
+		return results, nil
+	}
+
+	batch := make([]*domain.Link, len(links))
+	generated := make([]bool, len(links))
+	for i, link := range links {
+		batchLink, err := s.newLink(link, user)
+		if err != nil {
+			return nil, fmt.Errorf("usecase.CreateLinks: %w", err)
+		}
+		batch[i] = batchLink
+		generated[i] = batchLink.ShortenedString == ""
+	}
+
+	// generate shortened strings then create the batch, retrying only the
+	// generated strings which collide
+	retry := generated
+	for attempt := 0; attempt < shortCodeMaxAttempts; attempt++ {
+		for i, link := range batch {
+			if !retry[i] {
+				continue
+			}
+			shortenedString, err := s.generator.Generate(
+				ctx,
+				attempt/shortCodeAttemptsPerLength,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"usecase.CreateLinks: generator.Generate unhandled error: %w",
+					err,
+				)
+			}
+			link.ShortenedString = shortenedString
+		}
+
+		used, err := s.repo.CreateLinks(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"usecase.CreateLinks: repository.CreateLinks unhandled error: %w",
+				err,
+			)
+		}
+		if len(used) == 0 {
+			for i, link := range batch {
+				results[i] = &domain.LinkResult{Link: link}
+			}
+			return results, nil
+		}
+
+		retry = make([]bool, len(batch))
+		var failed bool
+		for _, i := range used {
+			if generated[i] {
+				retry[i] = true
+				continue
+			}
+			results[i] = &domain.LinkResult{Err: fmt.Errorf(
+				"usecase.CreateLinks: user given shortened string already used: %w",
+				domain_errors.ErrUsedShortenedString,
+			)}
+			failed = true
+		}
+		if failed {
+			return abortBatch(results), nil
+		}
+	}
+
+	for i := range batch {
+		if retry[i] {
+			results[i] = &domain.LinkResult{Err: fmt.Errorf(
+				"usecase.CreateLinks: %w after %d attempts",
+				domain_errors.ErrShortCodesExhausted,
+				shortCodeMaxAttempts,
+			)}
+		}
+	}
+	return abortBatch(results), nil
+}
+
+// abortBatch sets domain_errors.ErrBatchAborted as the result of links
+// without one
+func abortBatch(results []*domain.LinkResult) []*domain.LinkResult {
+	for i := range results {
+		if results[i] == nil {
+			results[i] = &domain.LinkResult{Err: fmt.Errorf(
+				"usecase.CreateLinks: another link failed: %w",
+				domain_errors.ErrBatchAborted,
+			)}
+		}
+	}
+	return results
+}
@@ -0,0 +1,289 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLinks(t *testing.T) {
+	type args struct {
+		links  []*domain.Link
+		atomic bool
+	}
+	type want struct {
+		results []*domain.LinkResult
+		err     error
+	}
+
+	user := &domain.User{Username: "username"}
+	abortedErr := fmt.Errorf(
+		"usecase.CreateLinks: another link failed: %w",
+		domain_errors.ErrBatchAborted,
+	)
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "partial",
+			args: args{
+				links: []*domain.Link{
+					{ShortenedString: "shortened_string", URL: "url"},
+					{ShortenedString: "used_shortened_string", URL: "url"},
+				},
+				atomic: false,
+			},
+			want: want{
+				results: []*domain.LinkResult{
+					{Link: &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "url",
+						Username:        "username",
+					}},
+					{Err: fmt.Errorf(
+						"usecase.CreateLink: user given shortened string already used: %w",
+						domain_errors.ErrUsedShortenedString,
+					)},
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						CreateLink(ctx, &domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+						}).
+						Return(nil),
+					m.repository.EXPECT().
+						CreateLink(ctx, &domain.Link{
+							ShortenedString: "used_shortened_string",
+							URL:             "url",
+							Username:        "username",
+						}).
+						Return(domain_errors.ErrUsedShortenedString),
+				)
+			},
+		},
+		{
+			name: "atomic Generate unhandled error",
+			args: args{
+				links:  []*domain.Link{{URL: "url"}},
+				atomic: true,
+			},
+			want: want{
+				results: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLinks: generator.Generate unhandled error: %w",
+					errors.New("Generate_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.generator.EXPECT().
+					Generate(ctx, 0).
+					Return("", errors.New("Generate_unhandled_error"))
+			},
+		},
+		{
+			name: "atomic CreateLinks unhandled error",
+			args: args{
+				links:  []*domain.Link{{ShortenedString: "shortened_string", URL: "url"}},
+				atomic: true,
+			},
+			want: want{
+				results: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLinks: repository.CreateLinks unhandled error: %w",
+					errors.New("CreateLinks_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					CreateLinks(ctx, gomock.Any()).
+					Return(nil, errors.New("CreateLinks_unhandled_error"))
+			},
+		},
+		{
+			name: "atomic used shortened string aborts batch",
+			args: args{
+				links: []*domain.Link{
+					{ShortenedString: "used_shortened_string", URL: "url"},
+					{URL: "url"},
+				},
+				atomic: true,
+			},
+			want: want{
+				results: []*domain.LinkResult{
+					{Err: fmt.Errorf(
+						"usecase.CreateLinks: user given shortened string already used: %w",
+						domain_errors.ErrUsedShortenedString,
+					)},
+					{Err: abortedErr},
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.generator.EXPECT().
+						Generate(ctx, 0).
+						Return("random_shortened_string", nil),
+					m.repository.EXPECT().
+						CreateLinks(ctx, []*domain.Link{
+							{
+								ShortenedString: "used_shortened_string",
+								URL:             "url",
+								Username:        "username",
+							},
+							{
+								ShortenedString: "random_shortened_string",
+								URL:             "url",
+								Username:        "username",
+							},
+						}).
+						Return([]int{0}, nil),
+				)
+			},
+		},
+		{
+			name: "atomic every generated shortened string collides",
+			args: args{
+				links: []*domain.Link{
+					{ShortenedString: "shortened_string", URL: "url"},
+					{URL: "url"},
+				},
+				atomic: true,
+			},
+			want: want{
+				results: []*domain.LinkResult{
+					{Err: abortedErr},
+					{Err: fmt.Errorf(
+						"usecase.CreateLinks: %w after 6 attempts",
+						domain_errors.ErrShortCodesExhausted,
+					)},
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				m.generator.EXPECT().
+					Generate(ctx, gomock.Any()).
+					Return("random_shortened_string", nil).
+					Times(6)
+				m.repository.EXPECT().
+					CreateLinks(ctx, gomock.Any()).
+					Return([]int{1}, nil).
+					Times(6)
+			},
+		},
+		{
+			name: "atomic ok after collisions escalating length",
+			args: args{
+				links: []*domain.Link{
+					{URL: "url_0"},
+					{ShortenedString: "shortened_string", URL: "url_1"},
+					{URL: "url_2"},
+				},
+				atomic: true,
+			},
+			want: want{
+				results: []*domain.LinkResult{
+					{Link: &domain.Link{
+						ShortenedString: "random_shortened_string_0",
+						URL:             "url_0",
+						Username:        "username",
+					}},
+					{Link: &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "url_1",
+						Username:        "username",
+					}},
+					{Link: &domain.Link{
+						ShortenedString: "longer_random_shortened_string",
+						URL:             "url_2",
+						Username:        "username",
+					}},
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				// only the colliding link gets a new shortened string
+				gomock.InOrder(
+					m.generator.EXPECT().
+						Generate(ctx, 0).
+						Return("random_shortened_string_0", nil),
+					m.generator.EXPECT().
+						Generate(ctx, 0).
+						Return("random_shortened_string_2", nil),
+					m.repository.EXPECT().
+						CreateLinks(ctx, gomock.Any()).
+						Return([]int{2}, nil),
+					m.generator.EXPECT().
+						Generate(ctx, 0).
+						Return("random_shortened_string_2", nil),
+					m.repository.EXPECT().
+						CreateLinks(ctx, gomock.Any()).
+						Return([]int{2}, nil),
+					m.generator.EXPECT().
+						Generate(ctx, 1).
+						Return("longer_random_shortened_string", nil),
+					m.repository.EXPECT().
+						CreateLinks(ctx, []*domain.Link{
+							{
+								ShortenedString: "random_shortened_string_0",
+								URL:             "url_0",
+								Username:        "username",
+							},
+							{
+								ShortenedString: "shortened_string",
+								URL:             "url_1",
+								Username:        "username",
+							},
+							{
+								ShortenedString: "longer_random_shortened_string",
+								URL:             "url_2",
+								Username:        "username",
+							},
+						}).
+						Return(nil, nil),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			results, err := service.CreateLinks(
+				ctx,
+				tt.args.links,
+				user,
+				tt.args.atomic,
+			)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.results, results)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+)
+
+// exportPageSize is the number of links read from the repository at once
+const exportPageSize = 500
+
+func (s *serviceUseCases) ExportLinks(
+	ctx context.Context,
+	user *domain.User,
+	write func(link *domain.Link) error,
+) error {
+	// pages are read from the last link of the previous one
+	var after *domain.Link
+	for {
+		links, err := s.repo.ListUserLinksAfter(
+			ctx,
+			user.Username,
+			after,
+			exportPageSize,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"usecase.ExportLinks: repository.ListUserLinksAfter unhandled error: %w",
+				err,
+			)
+		}
+
+		for _, link := range links {
+			if err := write(link); err != nil {
+				return fmt.Errorf("usecase.ExportLinks: write: %w", err)
+			}
+		}
+
+		if len(links) < exportPageSize {
+			return nil
+		}
+		after = links[len(links)-1]
+	}
+}
@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportLinks(t *testing.T) {
+	// newLinks returns n links numbered from first
+	newLinks := func(first int, n int) []*domain.Link {
+		links := make([]*domain.Link, 0, n)
+		for i := first; i < first+n; i++ {
+			links = append(links, &domain.Link{
+				ShortenedString: fmt.Sprintf("shortened_string_%d", i),
+				URL:             "url",
+				Username:        "username",
+			})
+		}
+		return links
+	}
+
+	type want struct {
+		written []*domain.Link
+		err     error
+	}
+
+	tests := []struct {
+		name  string
+		write func(link *domain.Link) error
+		want  want
+		mock  func(m mocks)
+	}{
+		{
+			name: "ListUserLinksAfter unhandled error",
+			want: want{
+				written: newLinks(0, 500),
+				err: fmt.Errorf(
+					"usecase.ExportLinks: repository.ListUserLinksAfter unhandled error: %w",
+					errors.New("ListUserLinksAfter_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						ListUserLinksAfter(ctx, "username", nil, 500).
+						Return(newLinks(0, 500), nil),
+					m.repository.EXPECT().
+						ListUserLinksAfter(ctx, "username", newLinks(499, 1)[0], 500).
+						Return(nil, errors.New("ListUserLinksAfter_unhandled_error")),
+				)
+			},
+		},
+		{
+			name: "write error",
+			write: func(link *domain.Link) error {
+				if link.ShortenedString == "shortened_string_1" {
+					return errors.New("write_error")
+				}
+				return nil
+			},
+			want: want{
+				written: newLinks(0, 2),
+				err: fmt.Errorf(
+					"usecase.ExportLinks: write: %w",
+					errors.New("write_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					ListUserLinksAfter(ctx, "username", nil, 500).
+					Return(newLinks(0, 3), nil)
+			},
+		},
+		{
+			name: "no links",
+			want: want{written: nil, err: nil},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					ListUserLinksAfter(ctx, "username", nil, 500).
+					Return(nil, nil)
+			},
+		},
+		{
+			name: "ok",
+			want: want{written: newLinks(0, 1200), err: nil},
+			mock: func(m mocks) {
+				// pages are read after the last link of the previous one until
+				// a page is not full
+				gomock.InOrder(
+					m.repository.EXPECT().
+						ListUserLinksAfter(ctx, "username", nil, 500).
+						Return(newLinks(0, 500), nil),
+					m.repository.EXPECT().
+						ListUserLinksAfter(ctx, "username", newLinks(499, 1)[0], 500).
+						Return(newLinks(500, 500), nil),
+					m.repository.EXPECT().
+						ListUserLinksAfter(ctx, "username", newLinks(999, 1)[0], 500).
+						Return(newLinks(1000, 200), nil),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			var written []*domain.Link
+			err := service.ExportLinks(
+				ctx,
+				&domain.User{Username: "username"},
+				func(link *domain.Link) error {
+					written = append(written, link)
+					if tt.write != nil {
+						return tt.write(link)
+					}
+					return nil
+				},
+			)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.written, written)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+)
+
+// number of top referrers and user agent families returned by GetLinkStats
+const statsTopLimit = 10
+
+func (s *serviceUseCases) GetLinkStats(
+	ctx context.Context,
+	user *domain.User,
+	shortenedString string,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) (*domain.LinkStats, error) {
+	_, err := s.getOwnedLink(ctx, user, shortenedString)
+	if err != nil {
+		return nil, fmt.Errorf("usecase.GetLinkStats: %w", err)
+	}
+
+	stats := &domain.LinkStats{From: from, To: to, Interval: interval}
+
+	stats.TotalClicks, stats.UniqueVisitors, err = s.repo.CountClicks(
+		ctx,
+		shortenedString,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"usecase.GetLinkStats: repository.CountClicks unhandled error: %w",
+			err,
+		)
+	}
+
+	buckets, err := s.repo.ListClickBuckets(
+		ctx,
+		shortenedString,
+		interval,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"usecase.GetLinkStats: repository.ListClickBuckets unhandled error: %w",
+			err,
+		)
+	}
+	stats.Series = fillStatsSeries(buckets, interval, from, to)
+
+	stats.TopReferrers, err = s.repo.ListTopReferrers(
+		ctx,
+		shortenedString,
+		from,
+		to,
+		statsTopLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"usecase.GetLinkStats: repository.ListTopReferrers unhandled error: %w",
+			err,
+		)
+	}
+
+	stats.TopUserAgents, err = s.repo.ListTopUserAgentFamilies(
+		ctx,
+		shortenedString,
+		from,
+		to,
+		statsTopLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"usecase.GetLinkStats: repository.ListTopUserAgentFamilies unhandled error: %w",
+			err,
+		)
+	}
+
+	return stats, nil
+}
+
+// fillStatsSeries returns a bucket for every interval in [from, to) taking
+// clicks from the buckets having any
+func fillStatsSeries(
+	buckets []*domain.StatsBucket,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) []*domain.StatsBucket {
+	byStart := make(map[int64]*domain.StatsBucket, len(buckets))
+	for _, bucket := range buckets {
+		byStart[bucket.Start.Unix()] = bucket
+	}
+
+	series := make([]*domain.StatsBucket, 0)
+	for start := interval.Truncate(from); start.Before(to); start = interval.Next(start) {
+		bucket := &domain.StatsBucket{Start: start}
+		if b, exists := byStart[start.Unix()]; exists {
+			bucket.Clicks = b.Clicks
+			bucket.UniqueVisitors = b.UniqueVisitors
+		}
+		series = append(series, bucket)
+	}
+
+	return series
+}
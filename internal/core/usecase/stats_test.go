@@ -0,0 +1,223 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLinkStats(t *testing.T) {
+	// from is not aligned to an interval so the first bucket starts before it
+	from := time.Date(2023, 4, 9, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2023, 4, 9, 13, 0, 0, 0, time.UTC)
+
+	ownedLink := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+	}
+
+	type want struct {
+		stats *domain.LinkStats
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "link not found",
+			want: want{
+				err: fmt.Errorf(
+					"usecase.GetLinkStats: %w",
+					fmt.Errorf(
+						"link don't exists: %w",
+						domain_errors.ErrLinkNotFound,
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(nil, domain_errors.ErrLinkNotFound)
+			},
+		},
+		{
+			name: "link not owned",
+			want: want{
+				err: fmt.Errorf(
+					"usecase.GetLinkStats: %w",
+					fmt.Errorf(
+						"link owned by another user: %w",
+						domain_errors.ErrLinkNotOwned,
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "another_username",
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "CountClicks unhandled error",
+			want: want{
+				err: fmt.Errorf(
+					"usecase.GetLinkStats: repository.CountClicks unhandled error: %w",
+					errors.New("CountClicks_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(ownedLink, nil)
+
+				m.repository.EXPECT().
+					CountClicks(ctx, "shortened_string", from, to).
+					Return(0, 0, errors.New("CountClicks_unhandled_error")).
+					After(getLinkCall)
+			},
+		},
+		{
+			name: "ListClickBuckets unhandled error",
+			want: want{
+				err: fmt.Errorf(
+					"usecase.GetLinkStats: repository.ListClickBuckets unhandled error: %w",
+					errors.New("ListClickBuckets_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(ownedLink, nil)
+				m.repository.EXPECT().
+					CountClicks(ctx, "shortened_string", from, to).
+					Return(0, 0, nil)
+				m.repository.EXPECT().
+					ListClickBuckets(ctx, "shortened_string", domain.StatsIntervalHour, from, to).
+					Return(nil, errors.New("ListClickBuckets_unhandled_error"))
+			},
+		},
+		{
+			name: "ok",
+			want: want{
+				stats: &domain.LinkStats{
+					From:           from,
+					To:             to,
+					Interval:       domain.StatsIntervalHour,
+					TotalClicks:    5,
+					UniqueVisitors: 2,
+					Series: []*domain.StatsBucket{
+						{
+							Start:          time.Date(2023, 4, 9, 10, 0, 0, 0, time.UTC),
+							Clicks:         3,
+							UniqueVisitors: 2,
+						},
+						{
+							Start: time.Date(2023, 4, 9, 11, 0, 0, 0, time.UTC),
+						},
+						{
+							Start:          time.Date(2023, 4, 9, 12, 0, 0, 0, time.UTC),
+							Clicks:         2,
+							UniqueVisitors: 1,
+						},
+					},
+					TopReferrers: []*domain.StatsCount{
+						{Value: "https://example.com", Count: 4},
+					},
+					TopUserAgents: []*domain.StatsCount{
+						{Value: "Firefox", Count: 3},
+						{Value: "Chrome", Count: 2},
+					},
+				},
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(ownedLink, nil)
+				m.repository.EXPECT().
+					CountClicks(ctx, "shortened_string", from, to).
+					Return(5, 2, nil)
+				m.repository.EXPECT().
+					ListClickBuckets(ctx, "shortened_string", domain.StatsIntervalHour, from, to).
+					Return(
+						[]*domain.StatsBucket{
+							{
+								Start:          time.Date(2023, 4, 9, 10, 0, 0, 0, time.UTC),
+								Clicks:         3,
+								UniqueVisitors: 2,
+							},
+							{
+								Start:          time.Date(2023, 4, 9, 12, 0, 0, 0, time.UTC),
+								Clicks:         2,
+								UniqueVisitors: 1,
+							},
+						},
+						nil,
+					)
+				m.repository.EXPECT().
+					ListTopReferrers(ctx, "shortened_string", from, to, 10).
+					Return(
+						[]*domain.StatsCount{
+							{Value: "https://example.com", Count: 4},
+						},
+						nil,
+					)
+				m.repository.EXPECT().
+					ListTopUserAgentFamilies(ctx, "shortened_string", from, to, 10).
+					Return(
+						[]*domain.StatsCount{
+							{Value: "Firefox", Count: 3},
+							{Value: "Chrome", Count: 2},
+						},
+						nil,
+					)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			stats, err := service.GetLinkStats(
+				ctx,
+				&domain.User{Username: "username"},
+				"shortened_string",
+				domain.StatsIntervalHour,
+				from,
+				to,
+			)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.stats, stats)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
 	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
@@ -11,20 +14,29 @@ import (
 
 type serviceUseCases struct {
 	repo      port.Repository
-	generator port.RandomStringGenerator
+	generator port.ShortCodeGenerator
+	hasher    port.PasswordHasher
+
+	// dummyHash is compared against the passwords of unknown users
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
 func NewService(
 	repo port.Repository,
-	generator port.RandomStringGenerator,
+	generator port.ShortCodeGenerator,
+	hasher port.PasswordHasher,
 ) port.ServiceUseCases {
-	return &serviceUseCases{repo: repo, generator: generator}
+	return &serviceUseCases{repo: repo, generator: generator, hasher: hasher}
 }
 
 func (s *serviceUseCases) GetLink(
+	ctx context.Context,
 	shortenedString string,
+	password string,
 ) (*domain.Link, error) {
-	link, err := s.repo.GetLink(shortenedString)
+	// check the password before counting the click
+	link, err := s.repo.GetLink(ctx, shortenedString)
 	if err != nil {
 		if errors.Is(err, domain_errors.ErrLinkNotFound) {
 			return nil, fmt.Errorf(
@@ -33,60 +45,201 @@ func (s *serviceUseCases) GetLink(
 		return nil, fmt.Errorf(
 			"usecase.GetLink: repository.GetLink unhandled error: %w", err)
 	}
+	if link.Expired(time.Now()) {
+		return nil, fmt.Errorf(
+			"usecase.GetLink: link expired: %w", domain_errors.ErrLinkExpired)
+	}
+	if link.Protected() {
+		if password == "" {
+			return nil, fmt.Errorf(
+				"usecase.GetLink: link password required: %w",
+				domain_errors.ErrLinkLocked,
+			)
+		}
+		err = s.hasher.Compare(link.Password, password)
+		if err != nil {
+			if errors.Is(err, domain_errors.ErrIncorrectPassword) {
+				return nil, fmt.Errorf(
+					"usecase.GetLink: link password don't match: %w", err)
+			}
+			return nil, fmt.Errorf(
+				"usecase.GetLink: hasher.Compare unhandled error: %w", err)
+		}
+	}
+
+	// clicks of links without max clicks are counted as they're saved by
+	// the click recorder, so following them needs no write
+	if !link.ClickLimited() {
+		return link, nil
+	}
+	link, err = s.repo.RegisterLinkClick(ctx, shortenedString, time.Now())
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrLinkNotFound) {
+			return nil, fmt.Errorf(
+				"usecase.GetLink: link don't exists: %w", err)
+		}
+		if errors.Is(err, domain_errors.ErrLinkExpired) {
+			return nil, fmt.Errorf(
+				"usecase.GetLink: link expired: %w", err)
+		}
+		return nil, fmt.Errorf(
+			"usecase.GetLink: repository.RegisterLinkClick unhandled error: %w",
+			err,
+		)
+	}
 	return link, nil
 }
 
-func (s *serviceUseCases) CreateLink(
-	url string,
+func (s *serviceUseCases) PreviewLink(
+	ctx context.Context,
 	shortenedString string,
-	user *domain.User,
 ) (*domain.Link, error) {
-	// check user exists
-	repoUser, err := s.repo.GetUser(user.Username)
+	link, err := s.repo.GetLink(ctx, shortenedString)
 	if err != nil {
-		if errors.Is(err, domain_errors.ErrUserNotFound) {
+		if errors.Is(err, domain_errors.ErrLinkNotFound) {
 			return nil, fmt.Errorf(
-				"usecase.CreateLink: user don't exists: %w", err)
+				"usecase.PreviewLink: link don't exists: %w", err)
 		}
 		return nil, fmt.Errorf(
-			"usecase.CreateLink: repository.GetUser unhandled error: %w", err)
+			"usecase.PreviewLink: repository.GetLink unhandled error: %w",
+			err,
+		)
 	}
-
-	// check the user password
-	if repoUser.Password != user.Password {
+	if link.Expired(time.Now()) {
 		return nil, fmt.Errorf(
-			"usecase.CreateLink: user password don't match: %w",
-			domain_errors.ErrIncorrectPassword,
+			"usecase.PreviewLink: link expired: %w",
+			domain_errors.ErrLinkExpired,
 		)
 	}
+	return link, nil
+}
 
-	if shortenedString != "" {
-		// check user given shortened string is not used
-		_, err := s.repo.GetLink(shortenedString)
-		if err == nil {
+func (s *serviceUseCases) CreateLink(
+	ctx context.Context,
+	link *domain.Link,
+	user *domain.User,
+) (*domain.Link, error) {
+	link, err := s.newLink(link, user)
+	if err != nil {
+		return nil, fmt.Errorf("usecase.CreateLink: %w", err)
+	}
+
+	if link.ShortenedString == "" {
+		// otherwise generate a short code
+		err := s.createLinkWithGeneratedString(ctx, link)
+		if err != nil {
+			return nil, fmt.Errorf("usecase.CreateLink: %w", err)
+		}
+		return link, nil
+	}
+
+	// create link, the repository rejects used shortened strings
+	err = s.repo.CreateLink(ctx, link)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrUsedShortenedString) {
 			return nil, fmt.Errorf(
 				"usecase.CreateLink: user given shortened string already used: %w",
-				domain_errors.ErrUsedShortenedString,
+				err,
 			)
-		} else if !errors.Is(err, domain_errors.ErrLinkNotFound) {
-			return nil, fmt.Errorf(
-				"usecase.CreateLink: repository.GetLink unhandled error: %w", err)
 		}
-	} else {
-		// otherwise generate a random string
-		shortenedString = s.generator.RandomString()
+		return nil, fmt.Errorf(
+			"usecase.CreateLink: repository.CreateLink unhandled error: %w",
+			err,
+		)
 	}
 
-	// create link
-	link := &domain.Link{
-		ShortenedString: shortenedString,
-		URL:             url,
-		Username:        repoUser.Username,
+	return link, nil
+}
+
+// newLink returns a link owned by user with the given fields of link and its
+// password hashed
+func (s *serviceUseCases) newLink(
+	link *domain.Link,
+	user *domain.User,
+) (*domain.Link, error) {
+	newLink := &domain.Link{
+		ShortenedString: link.ShortenedString,
+		URL:             link.URL,
+		Username:        user.Username,
+		ExpiresAt:       link.ExpiresAt,
+		MaxClicks:       link.MaxClicks,
+		RedirectType:    link.RedirectType,
+		Password:        link.Password,
 	}
-	err = s.repo.CreateLink(link)
+	if newLink.Protected() {
+		hash, err := s.hasher.Hash(newLink.Password)
+		if err != nil {
+			return nil, fmt.Errorf("hasher.Hash unhandled error: %w", err)
+		}
+		newLink.Password = hash
+	}
+	return newLink, nil
+}
+
+// shortened string generation retries on collisions with existing links,
+// escalating the length every shortCodeAttemptsPerLength attempts
+const (
+	shortCodeMaxAttempts       = 6
+	shortCodeAttemptsPerLength = 2
+)
+
+// createLinkWithGeneratedString sets link shortened string to a generated
+// short code and creates it, generating another one on collisions. codes
+// get a character longer every shortCodeAttemptsPerLength collisions as
+// those are likely with the keyspace crowded
+func (s *serviceUseCases) createLinkWithGeneratedString(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	for attempt := 0; attempt < shortCodeMaxAttempts; attempt++ {
+		shortenedString, err := s.generator.Generate(
+			ctx,
+			attempt/shortCodeAttemptsPerLength,
+		)
+		if err != nil {
+			return fmt.Errorf("generator.Generate unhandled error: %w", err)
+		}
+		link.ShortenedString = shortenedString
+
+		err = s.repo.CreateLink(ctx, link)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, domain_errors.ErrUsedShortenedString) {
+			return fmt.Errorf("repository.CreateLink unhandled error: %w", err)
+		}
+	}
+
+	return fmt.Errorf(
+		"%w after %d attempts",
+		domain_errors.ErrShortCodesExhausted,
+		shortCodeMaxAttempts,
+	)
+}
+
+func (s *serviceUseCases) UpdateLink(
+	ctx context.Context,
+	user *domain.User,
+	shortenedString string,
+	url string,
+	redirectType *domain.RedirectType,
+) (*domain.Link, error) {
+	link, err := s.getOwnedLink(ctx, user, shortenedString)
+	if err != nil {
+		return nil, fmt.Errorf("usecase.UpdateLink: %w", err)
+	}
+
+	link.URL = url
+	if redirectType != nil {
+		link.RedirectType = redirectType
+		if *redirectType == domain.RedirectDefault {
+			link.RedirectType = nil
+		}
+	}
+	err = s.repo.UpdateLink(ctx, link)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"usecase.CreateLink: repository.CreateLink unhandled error: %w",
+			"usecase.UpdateLink: repository.UpdateLink unhandled error: %w",
 			err,
 		)
 	}
@@ -94,11 +247,94 @@ func (s *serviceUseCases) CreateLink(
 	return link, nil
 }
 
+func (s *serviceUseCases) DeleteLink(
+	ctx context.Context,
+	user *domain.User,
+	shortenedString string,
+) error {
+	_, err := s.getOwnedLink(ctx, user, shortenedString)
+	if err != nil {
+		return fmt.Errorf("usecase.DeleteLink: %w", err)
+	}
+
+	err = s.repo.DeleteLink(ctx, shortenedString)
+	if err != nil {
+		return fmt.Errorf(
+			"usecase.DeleteLink: repository.DeleteLink unhandled error: %w",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *serviceUseCases) ListLinks(
+	ctx context.Context,
+	user *domain.User,
+	page int,
+	perPage int,
+) ([]*domain.Link, int, error) {
+	total, err := s.repo.CountUserLinks(ctx, user.Username)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"usecase.ListLinks: repository.CountUserLinks unhandled error: %w",
+			err,
+		)
+	}
+
+	// pages past the last are empty, which also keeps huge pages from
+	// overflowing the offset
+	if page-1 >= (total+perPage-1)/perPage {
+		return nil, total, nil
+	}
+
+	links, err := s.repo.ListUserLinks(
+		ctx,
+		user.Username,
+		(page-1)*perPage,
+		perPage,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"usecase.ListLinks: repository.ListUserLinks unhandled error: %w",
+			err,
+		)
+	}
+
+	return links, total, nil
+}
+
+// getOwnedLink returns domain_errors.ErrLinkNotFound if link don't exists and
+// domain_errors.ErrLinkNotOwned if it's owned by another user
+func (s *serviceUseCases) getOwnedLink(
+	ctx context.Context,
+	user *domain.User,
+	shortenedString string,
+) (*domain.Link, error) {
+	link, err := s.repo.GetLink(ctx, shortenedString)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrLinkNotFound) {
+			return nil, fmt.Errorf("link don't exists: %w", err)
+		}
+		return nil, fmt.Errorf("repository.GetLink unhandled error: %w", err)
+	}
+
+	if link.Username != user.Username {
+		return nil, fmt.Errorf(
+			"link owned by another user: %w",
+			domain_errors.ErrLinkNotOwned,
+		)
+	}
+
+	return link, nil
+}
+
 func (s *serviceUseCases) GetLinkUser(
+	ctx context.Context,
 	shortenedString string,
 ) (*domain.User, error) {
 	// get link
-	link, err := s.repo.GetLink(shortenedString)
+	link, err := s.repo.GetLink(ctx, shortenedString)
 	if err != nil {
 		if errors.Is(err, domain_errors.ErrLinkNotFound) {
 			return nil, fmt.Errorf(
@@ -109,7 +345,7 @@ func (s *serviceUseCases) GetLinkUser(
 	}
 
 	// get the user that created the link
-	user, err := s.repo.GetUser(link.Username)
+	user, err := s.repo.GetUser(ctx, link.Username)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"usecase.GetLinkUser: repository.GetUser unhandled error: %w", err)
@@ -120,22 +356,26 @@ func (s *serviceUseCases) GetLinkUser(
 	return user, nil
 }
 
-func (s *serviceUseCases) CreateUser(user *domain.User) error {
-	// check username is unique
-	_, err := s.repo.GetUser(user.Username)
-	if err == nil {
-		return fmt.Errorf(
-			"usecase.CreateUser: username already taken: %w",
-			domain_errors.ErrUsernameTaken,
-		)
-	} else if !errors.Is(err, domain_errors.ErrUserNotFound) {
+func (s *serviceUseCases) CreateUser(
+	ctx context.Context,
+	user *domain.User,
+) error {
+	// hash the password
+	hash, err := s.hasher.Hash(user.Password)
+	if err != nil {
 		return fmt.Errorf(
-			"usecase.CreateUser: repository.GetUser unhandled error: %w", err)
+			"usecase.CreateUser: hasher.Hash unhandled error: %w", err)
 	}
 
-	// create the user
-	err = s.repo.CreateUser(user)
+	// create the user, the repository rejects taken usernames
+	err = s.repo.CreateUser(ctx, &domain.User{
+		Username: user.Username,
+		Password: hash,
+	})
 	if err != nil {
+		if errors.Is(err, domain_errors.ErrUsernameTaken) {
+			return fmt.Errorf("usecase.CreateUser: username already taken: %w", err)
+		}
 		return fmt.Errorf(
 			"usecase.CreateUser: repository.CreateUser unhandled error: %w",
 			err,
@@ -1,9 +1,11 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
 	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
@@ -13,20 +15,44 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var ctx = context.Background()
+
 type mocks struct {
 	repository *mockups.MockRepository
-	generator  *mockups.MockRandomStringGenerator
+	generator  *mockups.MockShortCodeGenerator
+	hasher     *mockups.MockPasswordHasher
 }
 
 func TestGetLink(t *testing.T) {
 	type args struct {
 		shortenedString string
+		password        string
 	}
 	type want struct {
 		link *domain.Link
 		err  error
 	}
 
+	past := time.Now().Add(-time.Hour)
+	publicLink := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+	}
+	protectedLink := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+		Password:        "password_hash",
+	}
+	maxClicks := 3
+	limitedLink := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+		MaxClicks:       &maxClicks,
+	}
+
 	tests := []struct {
 		name string
 		args args
@@ -45,48 +71,1073 @@ func TestGetLink(t *testing.T) {
 			},
 			mock: func(m mocks) {
 				m.repository.EXPECT().
-					GetLink("shortened_string").
-					Return(nil, domain_errors.ErrLinkNotFound)
+					GetLink(ctx, "shortened_string").
+					Return(nil, domain_errors.ErrLinkNotFound)
+			},
+		},
+		{
+			name: "GetLink unhandled error",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: repository.GetLink unhandled error: %w",
+					errors.New("GetLink_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(nil, errors.New("GetLink_unhandled_error"))
+			},
+		},
+		{
+			name: "link expired",
+			args: args{shortenedString: "shortened_string", password: "password"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: link expired: %w",
+					domain_errors.ErrLinkExpired,
+				),
+			},
+			mock: func(m mocks) {
+				// password of expired links is not checked
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							ExpiresAt:       &past,
+							Password:        "password_hash",
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "link locked",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: link password required: %w",
+					domain_errors.ErrLinkLocked,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(protectedLink, nil)
+			},
+		},
+		{
+			name: "incorrect password",
+			args: args{shortenedString: "shortened_string", password: "password"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: link password don't match: %w",
+					domain_errors.ErrIncorrectPassword,
+				),
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(protectedLink, nil),
+					m.hasher.EXPECT().
+						Compare("password_hash", "password").
+						Return(domain_errors.ErrIncorrectPassword),
+				)
+			},
+		},
+		{
+			name: "Compare unhandled error",
+			args: args{shortenedString: "shortened_string", password: "password"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: hasher.Compare unhandled error: %w",
+					errors.New("Compare_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(protectedLink, nil),
+					m.hasher.EXPECT().
+						Compare("password_hash", "password").
+						Return(errors.New("Compare_unhandled_error")),
+				)
+			},
+		},
+		{
+			name: "link deleted before click",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: link don't exists: %w",
+					domain_errors.ErrLinkNotFound,
+				),
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(limitedLink, nil),
+					m.repository.EXPECT().
+						RegisterLinkClick(ctx, "shortened_string", gomock.Any()).
+						Return(nil, domain_errors.ErrLinkNotFound),
+				)
+			},
+		},
+		{
+			name: "link expired before click",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: link expired: %w",
+					domain_errors.ErrLinkExpired,
+				),
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(limitedLink, nil),
+					m.repository.EXPECT().
+						RegisterLinkClick(ctx, "shortened_string", gomock.Any()).
+						Return(nil, domain_errors.ErrLinkExpired),
+				)
+			},
+		},
+		{
+			name: "RegisterLinkClick unhandled error",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.GetLink: repository.RegisterLinkClick unhandled error: %w",
+					errors.New("RegisterLinkClick_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(limitedLink, nil),
+					m.repository.EXPECT().
+						RegisterLinkClick(ctx, "shortened_string", gomock.Any()).
+						Return(nil, errors.New("RegisterLinkClick_unhandled_error")),
+				)
+			},
+		},
+		{
+			name: "ok",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: publicLink,
+				err:  nil,
+			},
+			mock: func(m mocks) {
+				// clicks of links without max clicks are counted as saved
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(publicLink, nil)
+			},
+		},
+		{
+			name: "ok protected",
+			args: args{shortenedString: "shortened_string", password: "password"},
+			want: want{
+				link: protectedLink,
+				err:  nil,
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(protectedLink, nil),
+					m.hasher.EXPECT().
+						Compare("password_hash", "password").
+						Return(nil),
+				)
+			},
+		},
+		{
+			name: "ok limited",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+					Username:        "username",
+					MaxClicks:       &maxClicks,
+					ClickCount:      1,
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				gomock.InOrder(
+					m.repository.EXPECT().
+						GetLink(ctx, "shortened_string").
+						Return(limitedLink, nil),
+					m.repository.EXPECT().
+						RegisterLinkClick(ctx, "shortened_string", gomock.Any()).
+						Return(
+							&domain.Link{
+								ShortenedString: "shortened_string",
+								URL:             "url",
+								Username:        "username",
+								MaxClicks:       &maxClicks,
+								ClickCount:      1,
+							},
+							nil,
+						),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			link, err := service.GetLink(
+				ctx,
+				tt.args.shortenedString,
+				tt.args.password,
+			)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.link, link)
+		})
+	}
+}
+
+func TestPreviewLink(t *testing.T) {
+	type args struct {
+		shortenedString string
+	}
+	type want struct {
+		link *domain.Link
+		err  error
+	}
+
+	past := time.Now().Add(-time.Hour)
+	maxClicks := 3
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "link not found",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.PreviewLink: link don't exists: %w",
+					domain_errors.ErrLinkNotFound,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(nil, domain_errors.ErrLinkNotFound)
+			},
+		},
+		{
+			name: "GetLink unhandled error",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.PreviewLink: repository.GetLink unhandled error: %w",
+					errors.New("GetLink_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(nil, errors.New("GetLink_unhandled_error"))
+			},
+		},
+		{
+			name: "link expired",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.PreviewLink: link expired: %w",
+					domain_errors.ErrLinkExpired,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							ExpiresAt:       &past,
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "link max clicks reached",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.PreviewLink: link expired: %w",
+					domain_errors.ErrLinkExpired,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							MaxClicks:       &maxClicks,
+							ClickCount:      3,
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "ok",
+			args: args{shortenedString: "shortened_string"},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+					Username:        "username",
+					MaxClicks:       &maxClicks,
+					ClickCount:      2,
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				// no click is registered
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							MaxClicks:       &maxClicks,
+							ClickCount:      2,
+						},
+						nil,
+					)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			link, err := service.PreviewLink(ctx, tt.args.shortenedString)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.link, link)
+		})
+	}
+}
+
+func TestCreateLink(t *testing.T) {
+	type args struct {
+		link *domain.Link
+		user *domain.User
+	}
+	type want struct {
+		link *domain.Link
+		err  error
+	}
+
+	expiresAt := time.Date(2023, 3, 26, 9, 34, 15, 0, time.UTC)
+	maxClicks := 100
+	redirectType := domain.RedirectTemporaryRedirect
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "used shortened string",
+			args: args{
+				link: &domain.Link{
+					ShortenedString: "used_shortened_string",
+					URL:             "url",
+				},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLink: user given shortened string already used: %w",
+					domain_errors.ErrUsedShortenedString,
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					CreateLink(ctx, &domain.Link{
+						ShortenedString: "used_shortened_string",
+						URL:             "url",
+						Username:        "username",
+					}).
+					Return(domain_errors.ErrUsedShortenedString)
+			},
+		},
+		{
+			name: "CreateLink unhandled error with user given shortened string",
+			args: args{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+				},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLink: repository.CreateLink unhandled error: %w",
+					errors.New("CreateLink_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					CreateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "url",
+						Username:        "username",
+					}).
+					Return(errors.New("CreateLink_unhandled_error"))
+			},
+		},
+		{
+			name: "CreateLink unhandled error",
+			args: args{
+				link: &domain.Link{URL: "url"},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLink: %w",
+					fmt.Errorf(
+						"repository.CreateLink unhandled error: %w",
+						errors.New("CreateLink_unhandled_error"),
+					),
+				),
+			},
+			mock: func(m mocks) {
+				generateShortCode := m.generator.EXPECT().
+					Generate(ctx, 0).
+					Return("random_shortened_string", nil)
+
+				m.repository.EXPECT().
+					CreateLink(ctx, &domain.Link{
+						ShortenedString: "random_shortened_string",
+						URL:             "url",
+						Username:        "username",
+					}).
+					Return(errors.New("CreateLink_unhandled_error")).
+					After(generateShortCode)
+			},
+		},
+		{
+			name: "Generate unhandled error",
+			args: args{
+				link: &domain.Link{URL: "url"},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLink: %w",
+					fmt.Errorf(
+						"generator.Generate unhandled error: %w",
+						errors.New("Generate_unhandled_error"),
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.generator.EXPECT().
+					Generate(ctx, 0).
+					Return("", errors.New("Generate_unhandled_error"))
+			},
+		},
+		{
+			name: "every random shortened string collides",
+			args: args{
+				link: &domain.Link{URL: "url"},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLink: %w",
+					fmt.Errorf(
+						"%w after 6 attempts",
+						domain_errors.ErrShortCodesExhausted,
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.generator.EXPECT().
+					Generate(ctx, gomock.Any()).
+					Return("used_shortened_string", nil).
+					Times(6)
+				m.repository.EXPECT().
+					CreateLink(ctx, gomock.Any()).
+					Return(domain_errors.ErrUsedShortenedString).
+					Times(6)
+			},
+		},
+		{
+			name: "ok after collisions escalating length",
+			args: args{
+				link: &domain.Link{URL: "url"},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "random_shortened_string_2",
+					URL:             "url",
+					Username:        "username",
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				// two attempts per length before getting a character longer
+				gomock.InOrder(
+					m.generator.EXPECT().
+						Generate(ctx, 0).
+						Return("used_shortened_string_0", nil),
+					m.repository.EXPECT().
+						CreateLink(ctx, &domain.Link{
+							ShortenedString: "used_shortened_string_0",
+							URL:             "url",
+							Username:        "username",
+						}).
+						Return(domain_errors.ErrUsedShortenedString),
+					m.generator.EXPECT().
+						Generate(ctx, 0).
+						Return("used_shortened_string_1", nil),
+					m.repository.EXPECT().
+						CreateLink(ctx, &domain.Link{
+							ShortenedString: "used_shortened_string_1",
+							URL:             "url",
+							Username:        "username",
+						}).
+						Return(domain_errors.ErrUsedShortenedString),
+					m.generator.EXPECT().
+						Generate(ctx, 1).
+						Return("random_shortened_string_2", nil),
+					m.repository.EXPECT().
+						CreateLink(ctx, &domain.Link{
+							ShortenedString: "random_shortened_string_2",
+							URL:             "url",
+							Username:        "username",
+						}).
+						Return(nil),
+				)
+			},
+		},
+		{
+			name: "ok with user given shortened string",
+			args: args{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+				},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+					Username:        "username",
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					CreateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "url",
+						Username:        "username",
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name: "ok with lifecycle limits and redirect type",
+			args: args{
+				link: &domain.Link{
+					URL:          "url",
+					ExpiresAt:    &expiresAt,
+					MaxClicks:    &maxClicks,
+					RedirectType: &redirectType,
+				},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "random_shortened_string",
+					URL:             "url",
+					Username:        "username",
+					ExpiresAt:       &expiresAt,
+					MaxClicks:       &maxClicks,
+					RedirectType:    &redirectType,
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				generateShortCode := m.generator.EXPECT().
+					Generate(ctx, 0).
+					Return("random_shortened_string", nil)
+
+				m.repository.EXPECT().
+					CreateLink(ctx, &domain.Link{
+						ShortenedString: "random_shortened_string",
+						URL:             "url",
+						Username:        "username",
+						ExpiresAt:       &expiresAt,
+						MaxClicks:       &maxClicks,
+						RedirectType:    &redirectType,
+					}).
+					Return(nil).
+					After(generateShortCode)
+			},
+		},
+		{
+			name: "Hash unhandled error",
+			args: args{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+					Password:        "password",
+				},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.CreateLink: %w",
+					fmt.Errorf(
+						"hasher.Hash unhandled error: %w",
+						errors.New("Hash_unhandled_error"),
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.hasher.EXPECT().
+					Hash("password").
+					Return("", errors.New("Hash_unhandled_error"))
+			},
+		},
+		{
+			name: "ok with password",
+			args: args{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+					Password:        "password",
+				},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "url",
+					Username:        "username",
+					Password:        "password_hash",
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				// only the hash is saved
+				gomock.InOrder(
+					m.hasher.EXPECT().
+						Hash("password").
+						Return("password_hash", nil),
+					m.repository.EXPECT().
+						CreateLink(ctx, &domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							Password:        "password_hash",
+						}).
+						Return(nil),
+				)
+			},
+		},
+		{
+			name: "ok",
+			args: args{
+				link: &domain.Link{URL: "url"},
+				user: &domain.User{Username: "username"},
+			},
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "random_shortened_string",
+					URL:             "url",
+					Username:        "username",
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				generateShortCode := m.generator.EXPECT().
+					Generate(ctx, 0).
+					Return("random_shortened_string", nil)
+
+				m.repository.EXPECT().
+					CreateLink(ctx, &domain.Link{
+						ShortenedString: "random_shortened_string",
+						URL:             "url",
+						Username:        "username",
+					}).
+					Return(nil).
+					After(generateShortCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			link, err := service.CreateLink(ctx, tt.args.link, tt.args.user)
+
+			require.Equal(tt.want.err, err)
+			require.Equal(tt.want.link, link)
+		})
+	}
+}
+
+func TestUpdateLink(t *testing.T) {
+	type args struct {
+		user            *domain.User
+		shortenedString string
+		url             string
+		redirectType    *domain.RedirectType
+	}
+	type want struct {
+		link *domain.Link
+		err  error
+	}
+
+	defaultArgs := args{
+		user:            &domain.User{Username: "username"},
+		shortenedString: "shortened_string",
+		url:             "updated_url",
+	}
+
+	permanentRedirect := domain.RedirectPermanentRedirect
+	found := domain.RedirectFound
+	redirectDefault := domain.RedirectDefault
+
+	tests := []struct {
+		name string
+		args args
+		want want
+		mock func(m mocks)
+	}{
+		{
+			name: "link not found",
+			args: defaultArgs,
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.UpdateLink: %w",
+					fmt.Errorf(
+						"link don't exists: %w",
+						domain_errors.ErrLinkNotFound,
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(nil, domain_errors.ErrLinkNotFound)
+			},
+		},
+		{
+			name: "GetLink unhandled error",
+			args: defaultArgs,
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.UpdateLink: %w",
+					fmt.Errorf(
+						"repository.GetLink unhandled error: %w",
+						errors.New("GetLink_unhandled_error"),
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(nil, errors.New("GetLink_unhandled_error"))
+			},
+		},
+		{
+			name: "link not owned",
+			args: defaultArgs,
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.UpdateLink: %w",
+					fmt.Errorf(
+						"link owned by another user: %w",
+						domain_errors.ErrLinkNotOwned,
+					),
+				),
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "another_username",
+						},
+						nil,
+					)
+			},
+		},
+		{
+			name: "UpdateLink unhandled error",
+			args: defaultArgs,
+			want: want{
+				link: nil,
+				err: fmt.Errorf(
+					"usecase.UpdateLink: repository.UpdateLink unhandled error: %w",
+					errors.New("UpdateLink_unhandled_error"),
+				),
+			},
+			mock: func(m mocks) {
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+						},
+						nil,
+					)
+
+				m.repository.EXPECT().
+					UpdateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "updated_url",
+						Username:        "username",
+					}).
+					Return(errors.New("UpdateLink_unhandled_error")).
+					After(getLinkCall)
+			},
+		},
+		{
+			name: "ok",
+			args: defaultArgs,
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "updated_url",
+					Username:        "username",
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+						},
+						nil,
+					)
+
+				m.repository.EXPECT().
+					UpdateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "updated_url",
+						Username:        "username",
+					}).
+					Return(nil).
+					After(getLinkCall)
+			},
+		},
+		{
+			name: "ok keeping redirect type",
+			args: defaultArgs,
+			want: want{
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "updated_url",
+					Username:        "username",
+					RedirectType:    &permanentRedirect,
+				},
+				err: nil,
+			},
+			mock: func(m mocks) {
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							RedirectType:    &permanentRedirect,
+						},
+						nil,
+					)
+
+				m.repository.EXPECT().
+					UpdateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "updated_url",
+						Username:        "username",
+						RedirectType:    &permanentRedirect,
+					}).
+					Return(nil).
+					After(getLinkCall)
 			},
 		},
 		{
-			name: "GetLink unhandled error",
-			args: args{shortenedString: "shortened_string"},
+			name: "ok with redirect type",
+			args: args{
+				user:            &domain.User{Username: "username"},
+				shortenedString: "shortened_string",
+				url:             "updated_url",
+				redirectType:    &found,
+			},
 			want: want{
-				link: nil,
-				err: fmt.Errorf(
-					"usecase.GetLink: repository.GetLink unhandled error: %w",
-					errors.New("GetLink_unhandled_error"),
-				),
+				link: &domain.Link{
+					ShortenedString: "shortened_string",
+					URL:             "updated_url",
+					Username:        "username",
+					RedirectType:    &found,
+				},
+				err: nil,
 			},
 			mock: func(m mocks) {
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
+					Return(
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
+							RedirectType:    &permanentRedirect,
+						},
+						nil,
+					)
+
 				m.repository.EXPECT().
-					GetLink("shortened_string").
-					Return(nil, errors.New("GetLink_unhandled_error"))
+					UpdateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "updated_url",
+						Username:        "username",
+						RedirectType:    &found,
+					}).
+					Return(nil).
+					After(getLinkCall)
 			},
 		},
 		{
-			name: "ok",
-			args: args{shortenedString: "shortened_string"},
+			name: "ok resetting redirect type",
+			args: args{
+				user:            &domain.User{Username: "username"},
+				shortenedString: "shortened_string",
+				url:             "updated_url",
+				redirectType:    &redirectDefault,
+			},
 			want: want{
 				link: &domain.Link{
 					ShortenedString: "shortened_string",
-					URL:             "url",
+					URL:             "updated_url",
 					Username:        "username",
 				},
 				err: nil,
 			},
 			mock: func(m mocks) {
-				m.repository.EXPECT().
-					GetLink("shortened_string").
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
 					Return(
 						&domain.Link{
 							ShortenedString: "shortened_string",
 							URL:             "url",
 							Username:        "username",
+							RedirectType:    &permanentRedirect,
 						},
 						nil,
 					)
+
+				m.repository.EXPECT().
+					UpdateLink(ctx, &domain.Link{
+						ShortenedString: "shortened_string",
+						URL:             "updated_url",
+						Username:        "username",
+					}).
+					Return(nil).
+					After(getLinkCall)
 			},
 		},
 	}
@@ -98,13 +1149,20 @@ func TestGetLink(t *testing.T) {
 			controller := gomock.NewController(t)
 			m := mocks{
 				repository: mockups.NewMockRepository(controller),
-				generator:  mockups.NewMockRandomStringGenerator(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
 			}
 
 			tt.mock(m)
-			service := usecase.NewService(m.repository, m.generator)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
 
-			link, err := service.GetLink(tt.args.shortenedString)
+			link, err := service.UpdateLink(
+				ctx,
+				tt.args.user,
+				tt.args.shortenedString,
+				tt.args.url,
+				tt.args.redirectType,
+			)
 
 			require.Equal(tt.want.err, err)
 			require.Equal(tt.want.link, link)
@@ -112,253 +1170,209 @@ func TestGetLink(t *testing.T) {
 	}
 }
 
-func TestCreateLink(t *testing.T) {
-	type args struct {
-		url             string
-		shortenedString string
-		user            *domain.User
-	}
+func TestDeleteLink(t *testing.T) {
 	type want struct {
-		link *domain.Link
-		err  error
+		err error
 	}
 
 	tests := []struct {
 		name string
-		args args
 		want want
 		mock func(m mocks)
 	}{
 		{
-			name: "user not found",
-			args: args{
-				url: "url",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
-			want: want{
-				link: nil,
-				err: fmt.Errorf(
-					"usecase.CreateLink: user don't exists: %w",
-					domain_errors.ErrUserNotFound,
-				),
-			},
-			mock: func(m mocks) {
-				m.repository.EXPECT().
-					GetUser("username").
-					Return(nil, domain_errors.ErrUserNotFound)
-			},
-		},
-		{
-			name: "GetUser unhandled error",
-			args: args{
-				url: "url",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
+			name: "link not found",
 			want: want{
-				link: nil,
 				err: fmt.Errorf(
-					"usecase.CreateLink: repository.GetUser unhandled error: %w",
-					errors.New("GetUser_unhandled_error"),
+					"usecase.DeleteLink: %w",
+					fmt.Errorf(
+						"link don't exists: %w",
+						domain_errors.ErrLinkNotFound,
+					),
 				),
 			},
 			mock: func(m mocks) {
 				m.repository.EXPECT().
-					GetUser("username").
-					Return(nil, errors.New("GetUser_unhandled_error"))
+					GetLink(ctx, "shortened_string").
+					Return(nil, domain_errors.ErrLinkNotFound)
 			},
 		},
 		{
-			name: "incorrect password",
-			args: args{
-				url: "url",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
+			name: "link not owned",
 			want: want{
-				link: nil,
 				err: fmt.Errorf(
-					"usecase.CreateLink: user password don't match: %w",
-					domain_errors.ErrIncorrectPassword,
+					"usecase.DeleteLink: %w",
+					fmt.Errorf(
+						"link owned by another user: %w",
+						domain_errors.ErrLinkNotOwned,
+					),
 				),
 			},
 			mock: func(m mocks) {
 				m.repository.EXPECT().
-					GetUser("username").
+					GetLink(ctx, "shortened_string").
 					Return(
-						&domain.User{
-							Username: "username",
-							Password: "unmatched_password",
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "another_username",
 						},
 						nil,
 					)
 			},
 		},
 		{
-			name: "used shortened string",
-			args: args{
-				url:             "url",
-				shortenedString: "used_shortened_string",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
+			name: "DeleteLink unhandled error",
 			want: want{
-				link: nil,
 				err: fmt.Errorf(
-					"usecase.CreateLink: user given shortened string already used: %w",
-					domain_errors.ErrUsedShortenedString,
+					"usecase.DeleteLink: repository.DeleteLink unhandled error: %w",
+					errors.New("DeleteLink_unhandled_error"),
 				),
 			},
 			mock: func(m mocks) {
-				getUserCall := m.repository.EXPECT().
-					GetUser("username").
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
 					Return(
-						&domain.User{
-							Username: "username",
-							Password: "password",
+						&domain.Link{
+							ShortenedString: "shortened_string",
+							URL:             "url",
+							Username:        "username",
 						},
 						nil,
 					)
 
 				m.repository.EXPECT().
-					GetLink("used_shortened_string").
+					DeleteLink(ctx, "shortened_string").
+					Return(errors.New("DeleteLink_unhandled_error")).
+					After(getLinkCall)
+			},
+		},
+		{
+			name: "ok",
+			want: want{err: nil},
+			mock: func(m mocks) {
+				getLinkCall := m.repository.EXPECT().
+					GetLink(ctx, "shortened_string").
 					Return(
 						&domain.Link{
-							ShortenedString: "used_shortened_string",
+							ShortenedString: "shortened_string",
 							URL:             "url",
-							Username:        "perhaps_or_perhaps_not_another_user",
+							Username:        "username",
 						},
 						nil,
-					).
-					After(getUserCall)
+					)
+
+				m.repository.EXPECT().
+					DeleteLink(ctx, "shortened_string").
+					Return(nil).
+					After(getLinkCall)
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			controller := gomock.NewController(t)
+			m := mocks{
+				repository: mockups.NewMockRepository(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
+			}
+
+			tt.mock(m)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
+
+			err := service.DeleteLink(
+				ctx,
+				&domain.User{Username: "username"},
+				"shortened_string",
+			)
+
+			require.Equal(tt.want.err, err)
+		})
+	}
+}
+
+func TestListLinks(t *testing.T) {
+	type want struct {
+		links []*domain.Link
+		total int
+		err   error
+	}
+
+	links := []*domain.Link{
+		{ShortenedString: "link03", URL: "url", Username: "username"},
+	}
+
+	tests := []struct {
+		name string
+		want want
+		mock func(m mocks)
+	}{
 		{
-			name: "GetLink unhandled error",
-			args: args{
-				url:             "url",
-				shortenedString: "shortened_string",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
+			name: "CountUserLinks unhandled error",
 			want: want{
-				link: nil,
 				err: fmt.Errorf(
-					"usecase.CreateLink: repository.GetLink unhandled error: %w",
-					errors.New("GetLink_unhandled_error"),
+					"usecase.ListLinks: repository.CountUserLinks unhandled error: %w",
+					errors.New("CountUserLinks_unhandled_error"),
 				),
 			},
 			mock: func(m mocks) {
-				getUserCall := m.repository.EXPECT().
-					GetUser("username").
-					Return(
-						&domain.User{
-							Username: "username",
-							Password: "password",
-						},
-						nil,
-					)
-
 				m.repository.EXPECT().
-					GetLink("shortened_string").
-					Return(nil, errors.New("GetLink_unhandled_error")).
-					After(getUserCall)
+					CountUserLinks(ctx, "username").
+					Return(0, errors.New("CountUserLinks_unhandled_error"))
 			},
 		},
 		{
-			name: "CreateLink unhandled error",
-			args: args{
-				url: "url",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
+			name: "ListUserLinks unhandled error",
 			want: want{
-				link: nil,
 				err: fmt.Errorf(
-					"usecase.CreateLink: repository.CreateLink unhandled error: %w",
-					errors.New("CreateLink_unhandled_error"),
+					"usecase.ListLinks: repository.ListUserLinks unhandled error: %w",
+					errors.New("ListUserLinks_unhandled_error"),
 				),
 			},
 			mock: func(m mocks) {
-				getUserCall := m.repository.EXPECT().
-					GetUser("username").
-					Return(
-						&domain.User{
-							Username: "username",
-							Password: "password",
-						},
-						nil,
-					)
-
-				generateRandomString := m.generator.EXPECT().
-					RandomString().
-					Return("random_shortened_string").
-					After(getUserCall)
+				countUserLinksCall := m.repository.EXPECT().
+					CountUserLinks(ctx, "username").
+					Return(3, nil)
 
 				m.repository.EXPECT().
-					CreateLink(&domain.Link{
-						ShortenedString: "random_shortened_string",
-						URL:             "url",
-						Username:        "username",
-					}).
-					Return(errors.New("CreateLink_unhandled_error")).
-					After(generateRandomString)
+					ListUserLinks(ctx, "username", 2, 2).
+					Return(nil, errors.New("ListUserLinks_unhandled_error")).
+					After(countUserLinksCall)
 			},
 		},
 		{
 			name: "ok",
-			args: args{
-				url: "url",
-				user: &domain.User{
-					Username: "username",
-					Password: "password",
-				},
-			},
 			want: want{
-				link: &domain.Link{
-					ShortenedString: "random_shortened_string",
-					URL:             "url",
-					Username:        "username",
-				},
-				err: nil,
+				links: links,
+				total: 3,
+				err:   nil,
 			},
 			mock: func(m mocks) {
-				getUserCall := m.repository.EXPECT().
-					GetUser("username").
-					Return(
-						&domain.User{
-							Username: "username",
-							Password: "password",
-						},
-						nil,
-					)
-
-				generateRandomString := m.generator.EXPECT().
-					RandomString().
-					Return("random_shortened_string").
-					After(getUserCall)
+				countUserLinksCall := m.repository.EXPECT().
+					CountUserLinks(ctx, "username").
+					Return(3, nil)
 
 				m.repository.EXPECT().
-					CreateLink(&domain.Link{
-						ShortenedString: "random_shortened_string",
-						URL:             "url",
-						Username:        "username",
-					}).
-					Return(nil).
-					After(generateRandomString)
+					ListUserLinks(ctx, "username", 2, 2).
+					Return(links, nil).
+					After(countUserLinksCall)
+			},
+		},
+		{
+			name: "page past the last",
+			want: want{
+				links: nil,
+				total: 2,
+				err:   nil,
+			},
+			mock: func(m mocks) {
+				m.repository.EXPECT().
+					CountUserLinks(ctx, "username").
+					Return(2, nil)
 			},
 		},
 	}
@@ -370,20 +1384,24 @@ func TestCreateLink(t *testing.T) {
 			controller := gomock.NewController(t)
 			m := mocks{
 				repository: mockups.NewMockRepository(controller),
-				generator:  mockups.NewMockRandomStringGenerator(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
 			}
 
 			tt.mock(m)
-			service := usecase.NewService(m.repository, m.generator)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
 
-			link, err := service.CreateLink(
-				tt.args.url,
-				tt.args.shortenedString,
-				tt.args.user,
+			// second page of two links per page
+			links, total, err := service.ListLinks(
+				ctx,
+				&domain.User{Username: "username"},
+				2,
+				2,
 			)
 
 			require.Equal(tt.want.err, err)
-			require.Equal(tt.want.link, link)
+			require.Equal(tt.want.links, links)
+			require.Equal(tt.want.total, total)
 		})
 	}
 }
@@ -415,7 +1433,7 @@ func TestGetLinkUser(t *testing.T) {
 			},
 			mock: func(m mocks) {
 				m.repository.EXPECT().
-					GetLink("shortened_string").
+					GetLink(ctx, "shortened_string").
 					Return(nil, domain_errors.ErrLinkNotFound)
 			},
 		},
@@ -431,7 +1449,7 @@ func TestGetLinkUser(t *testing.T) {
 			},
 			mock: func(m mocks) {
 				m.repository.EXPECT().
-					GetLink("shortened_string").
+					GetLink(ctx, "shortened_string").
 					Return(nil, errors.New("GetLink_unhandled_error"))
 			},
 		},
@@ -447,7 +1465,7 @@ func TestGetLinkUser(t *testing.T) {
 			},
 			mock: func(m mocks) {
 				getLinkCall := m.repository.EXPECT().
-					GetLink("shortened_string").
+					GetLink(ctx, "shortened_string").
 					Return(
 						&domain.Link{
 							ShortenedString: "shortened_string",
@@ -458,7 +1476,7 @@ func TestGetLinkUser(t *testing.T) {
 					)
 
 				m.repository.EXPECT().
-					GetUser("username").
+					GetUser(ctx, "username").
 					Return(nil, errors.New("GetUser_unhandled_error")).
 					After(getLinkCall)
 			},
@@ -475,7 +1493,7 @@ func TestGetLinkUser(t *testing.T) {
 			},
 			mock: func(m mocks) {
 				getLinkCall := m.repository.EXPECT().
-					GetLink("shortened_string").
+					GetLink(ctx, "shortened_string").
 					Return(
 						&domain.Link{
 							ShortenedString: "shortened_string",
@@ -486,7 +1504,7 @@ func TestGetLinkUser(t *testing.T) {
 					)
 
 				m.repository.EXPECT().
-					GetUser("username").
+					GetUser(ctx, "username").
 					Return(
 						&domain.User{
 							Username: "username",
@@ -506,13 +1524,14 @@ func TestGetLinkUser(t *testing.T) {
 			controller := gomock.NewController(t)
 			m := mocks{
 				repository: mockups.NewMockRepository(controller),
-				generator:  mockups.NewMockRandomStringGenerator(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
 			}
 
 			tt.mock(m)
-			service := usecase.NewService(m.repository, m.generator)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
 
-			link, err := service.GetLinkUser(tt.args.shortenedString)
+			link, err := service.GetLinkUser(ctx, tt.args.shortenedString)
 
 			require.Equal(tt.want.err, err)
 			require.Equal(tt.want.user, link)
@@ -540,39 +1559,41 @@ func TestCreateUser(t *testing.T) {
 		mock func(m mocks)
 	}{
 		{
-			name: "username taken",
+			name: "Hash unhandled error",
 			args: args{user: user},
 			want: want{
 				err: fmt.Errorf(
-					"usecase.CreateUser: username already taken: %w",
-					domain_errors.ErrUsernameTaken,
+					"usecase.CreateUser: hasher.Hash unhandled error: %w",
+					errors.New("Hash_unhandled_error"),
 				),
 			},
 			mock: func(m mocks) {
-				m.repository.EXPECT().
-					GetUser(user.Username).
-					Return(
-						&domain.User{
-							Username: "username",
-							Password: "perhaps_or_perhaps_not_another_password",
-						},
-						nil,
-					)
+				m.hasher.EXPECT().
+					Hash(user.Password).
+					Return("", errors.New("Hash_unhandled_error"))
 			},
 		},
 		{
-			name: "GetUser unhandled error",
+			name: "username taken",
 			args: args{user: user},
 			want: want{
 				err: fmt.Errorf(
-					"usecase.CreateUser: repository.GetUser unhandled error: %w",
-					errors.New("GetUser_unhandled_error"),
+					"usecase.CreateUser: username already taken: %w",
+					domain_errors.ErrUsernameTaken,
 				),
 			},
 			mock: func(m mocks) {
+				hashCall := m.hasher.EXPECT().
+					Hash(user.Password).
+					Return("password_hash", nil)
+
 				m.repository.EXPECT().
-					GetUser(user.Username).
-					Return(nil, errors.New("GetUser_unhandled_error"))
+					CreateUser(ctx, &domain.User{
+						Username: user.Username,
+						Password: "password_hash",
+					}).
+					Return(domain_errors.ErrUsernameTaken).
+					After(hashCall)
 			},
 		},
 		{
@@ -585,14 +1606,17 @@ func TestCreateUser(t *testing.T) {
 				),
 			},
 			mock: func(m mocks) {
-				getUserCall := m.repository.EXPECT().
-					GetUser(user.Username).
-					Return(nil, domain_errors.ErrUserNotFound)
+				hashCall := m.hasher.EXPECT().
+					Hash(user.Password).
+					Return("password_hash", nil)
 
 				m.repository.EXPECT().
-					CreateUser(user).
+					CreateUser(ctx, &domain.User{
+						Username: user.Username,
+						Password: "password_hash",
+					}).
 					Return(errors.New("CreateUser_unhandled_error")).
-					After(getUserCall)
+					After(hashCall)
 			},
 		},
 		{
@@ -602,14 +1626,17 @@ func TestCreateUser(t *testing.T) {
 				err: nil,
 			},
 			mock: func(m mocks) {
-				getUserCall := m.repository.EXPECT().
-					GetUser(user.Username).
-					Return(nil, domain_errors.ErrUserNotFound)
+				hashCall := m.hasher.EXPECT().
+					Hash(user.Password).
+					Return("password_hash", nil)
 
 				m.repository.EXPECT().
-					CreateUser(user).
+					CreateUser(ctx, &domain.User{
+						Username: user.Username,
+						Password: "password_hash",
+					}).
 					Return(nil).
-					After(getUserCall)
+					After(hashCall)
 			},
 		},
 	}
@@ -621,13 +1648,14 @@ func TestCreateUser(t *testing.T) {
 			controller := gomock.NewController(t)
 			m := mocks{
 				repository: mockups.NewMockRepository(controller),
-				generator:  mockups.NewMockRandomStringGenerator(controller),
+				generator:  mockups.NewMockShortCodeGenerator(controller),
+				hasher:     mockups.NewMockPasswordHasher(controller),
 			}
 
 			tt.mock(m)
-			service := usecase.NewService(m.repository, m.generator)
+			service := usecase.NewService(m.repository, m.generator, m.hasher)
 
-			err := service.CreateUser(tt.args.user)
+			err := service.CreateUser(ctx, tt.args.user)
 
 			require.Equal(tt.want.err, err)
 		})
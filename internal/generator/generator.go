@@ -1,6 +1,9 @@
 package generator
 
-import "math/rand"
+import (
+	"context"
+	"math/rand"
+)
 
 type generator struct {
 	length int
@@ -13,11 +16,10 @@ func NewRandomStringGenerator(length int) generator {
 	return generator{length: length}
 }
 
-func (g generator) RandomString() string {
-	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, g.length)
+func (g generator) Generate(_ context.Context, extraLength int) (string, error) {
+	b := make([]byte, g.length+extraLength)
 	for i := range b {
-		b[i] = letters[rand.Intn(len(letters))]
+		b[i] = AlphabetBase62[rand.Intn(len(AlphabetBase62))]
 	}
-	return string(b)
+	return string(b), nil
 }
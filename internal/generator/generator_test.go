@@ -1,6 +1,7 @@
 package generator_test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var ctx = context.Background()
+
 var alphanumericRegexp = regexp.MustCompile("^[a-zA-Z0-9]+$")
 
 func TestRandomString(t *testing.T) {
@@ -57,16 +60,142 @@ func TestRandomString(t *testing.T) {
 						g := generator.NewRandomStringGenerator(
 							tt.fields.length,
 						)
-						g.RandomString()
+						g.Generate(ctx, 0)
 					},
 				)
 			} else {
 				g := generator.NewRandomStringGenerator(tt.fields.length)
-				randomString := g.RandomString()
+				randomString, err := g.Generate(ctx, 0)
+				require.NoError(err)
 
 				require.Len(randomString, tt.fields.length)
 				require.Regexp(alphanumericRegexp, randomString)
+
+				// extra length is added to the generator's length
+				randomString, err = g.Generate(ctx, 2)
+				require.NoError(err)
+
+				require.Len(randomString, tt.fields.length+2)
+				require.Regexp(alphanumericRegexp, randomString)
+			}
+		})
+	}
+}
+
+func TestSecureRandomString(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   int
+		alphabet string
+		regexp   string
+	}{
+		{
+			name:     "base62",
+			length:   6,
+			alphabet: generator.AlphabetBase62,
+			regexp:   "^[a-zA-Z0-9]+$",
+		},
+		{
+			name:     "unambiguous",
+			length:   32,
+			alphabet: generator.AlphabetUnambiguous,
+			regexp:   "^[^0O1lI]+$",
+		},
+		{
+			name:     "lowercase",
+			length:   8,
+			alphabet: generator.AlphabetLowercase,
+			regexp:   "^[a-z0-9]+$",
+		},
+		{
+			name:     "lowercase unambiguous",
+			length:   8,
+			alphabet: generator.AlphabetLowercaseUnambiguous,
+			regexp:   "^[a-hjkmnp-z2-9]+$",
+		},
+		{
+			name:     "custom",
+			length:   10,
+			alphabet: "xyz-_",
+			regexp:   "^[xyz_-]+$",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			g := generator.NewSecureRandomStringGenerator(tt.length, tt.alphabet)
+			for extraLength := 0; extraLength < 3; extraLength++ {
+				randomString, err := g.Generate(ctx, extraLength)
+				require.NoError(err)
+
+				require.Len(randomString, tt.length+extraLength)
+				require.Regexp(tt.regexp, randomString)
 			}
 		})
 	}
 }
+
+func TestSecureRandomStringIsUniform(t *testing.T) {
+	require := require.New(t)
+
+	// 3 characters need 2 random bits so a quarter of draws are rejected,
+	// taking them modulo instead would favor the first character
+	g := generator.NewSecureRandomStringGenerator(30, "abc")
+	counts := make(map[rune]int)
+	for i := 0; i < 1000; i++ {
+		randomString, err := g.Generate(ctx, 0)
+		require.NoError(err)
+		for _, c := range randomString {
+			counts[c]++
+		}
+	}
+
+	require.Len(counts, 3)
+	for _, count := range counts {
+		// 10000 expected, the standard deviation is about 82
+		require.InDelta(10000, count, 600)
+	}
+}
+
+func TestValidateAlphabet(t *testing.T) {
+	require := require.New(t)
+
+	for _, alphabet := range generator.Alphabets {
+		require.NoError(generator.ValidateAlphabet(alphabet))
+	}
+	require.NoError(generator.ValidateAlphabet("._~-"))
+	require.EqualError(
+		generator.ValidateAlphabet("a"),
+		"alphabet should have at least 2 characters",
+	)
+	require.EqualError(
+		generator.ValidateAlphabet("abca"),
+		"alphabet should have unique url unreserved characters",
+	)
+	require.EqualError(
+		generator.ValidateAlphabet("abc/"),
+		"alphabet should have unique url unreserved characters",
+	)
+}
+
+func TestNewSecureRandomStringGeneratorPanics(t *testing.T) {
+	require := require.New(t)
+
+	require.PanicsWithValue(
+		"generator: length should not be less than 6 or greater than 32",
+		func() { generator.NewSecureRandomStringGenerator(5, generator.AlphabetBase62) },
+	)
+	require.PanicsWithValue(
+		"generator: alphabet should have at least 2 characters",
+		func() { generator.NewSecureRandomStringGenerator(6, "a") },
+	)
+	require.PanicsWithValue(
+		"generator: alphabet should have unique url unreserved characters",
+		func() { generator.NewSecureRandomStringGenerator(6, "abca") },
+	)
+	require.PanicsWithValue(
+		"generator: alphabet should have unique url unreserved characters",
+		func() { generator.NewSecureRandomStringGenerator(6, "abc/") },
+	)
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+const (
+	AlphabetBase62 = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// AlphabetUnambiguous is base62 without the confusable 0, O, 1, l and I
+	AlphabetUnambiguous = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	// AlphabetLowercase generates codes without uppercase letters, codes are
+	// still matched case-sensitively so custom codes may hold uppercase ones
+	AlphabetLowercase = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// AlphabetLowercaseUnambiguous is lowercase without 0, o, 1, l and i
+	AlphabetLowercaseUnambiguous = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// Alphabets maps names of the predefined alphabets to them
+var Alphabets = map[string]string{
+	"base62":                AlphabetBase62,
+	"unambiguous":           AlphabetUnambiguous,
+	"lowercase":             AlphabetLowercase,
+	"lowercase-unambiguous": AlphabetLowercaseUnambiguous,
+}
+
+// secureGenerator draws characters uniformly from alphabet using crypto/rand
+type secureGenerator struct {
+	length   int
+	alphabet string
+	// mask keeps the random bits needed to index alphabet
+	mask byte
+}
+
+func NewSecureRandomStringGenerator(length int, alphabet string) port.ShortCodeGenerator {
+	if length < 6 || length > 32 {
+		panic("generator: length should not be less than 6 or greater than 32")
+	}
+	checkAlphabet(alphabet)
+
+	mask := byte(1)
+	for int(mask) < len(alphabet)-1 {
+		mask = mask<<1 | 1
+	}
+	return secureGenerator{length: length, alphabet: alphabet, mask: mask}
+}
+
+// ValidateAlphabet returns an error if alphabet can't be used in codes
+func ValidateAlphabet(alphabet string) error {
+	if len(alphabet) < 2 {
+		return errors.New("alphabet should have at least 2 characters")
+	}
+	seen := make(map[rune]bool, len(alphabet))
+	for _, c := range alphabet {
+		if !isUnreserved(c) || seen[c] {
+			return errors.New("alphabet should have unique url unreserved characters")
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// checkAlphabet panics if alphabet can't be used in codes
+func checkAlphabet(alphabet string) {
+	if err := ValidateAlphabet(alphabet); err != nil {
+		panic("generator: " + err.Error())
+	}
+}
+
+// isUnreserved reports whether c is allowed unescaped in urls (RFC 3986)
+func isUnreserved(c rune) bool {
+	return 'a' <= c && c <= 'z' ||
+		'A' <= c && c <= 'Z' ||
+		'0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func (g secureGenerator) Generate(_ context.Context, extraLength int) (string, error) {
+	b := make([]byte, g.length+extraLength)
+	// masked bytes out of alphabet are rejected rather than taken modulo
+	// so every character is equally likely
+	buf := make([]byte, len(b)*2)
+	for i := 0; i < len(b); {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("generator: crypto/rand failed: %w", err)
+		}
+		for _, r := range buf {
+			if r &= g.mask; int(r) < len(g.alphabet) {
+				b[i] = g.alphabet[r]
+				if i++; i == len(b) {
+					break
+				}
+			}
+		}
+	}
+	return string(b), nil
+}
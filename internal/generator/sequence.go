@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+var errSequenceExhausted = errors.New("generator: id sequence exhausted")
+
+type SequenceOptions struct {
+	// Length is the minimum code length, codes get longer as ids outgrow
+	// the keyspace of Length characters
+	Length   int
+	Alphabet string
+	// BlockSize ids are reserved per storage round trip, it should be the
+	// same for every replica sharing the storage
+	BlockSize int64
+	// Key enables obfuscating ids with a keyed permutation if not empty. it
+	// should be kept secret and never change
+	Key []byte
+}
+
+const DefaultSequenceBlockSize = 100
+
+// sequenceGenerator encodes ids taken from blocks reserved in the
+// repository, so codes never collide even across replicas
+type sequenceGenerator struct {
+	repo port.Repository
+	opts SequenceOptions
+
+	mu sync.Mutex
+	// ids in [next, end) are reserved and unused
+	next int64
+	end  int64
+}
+
+func NewSequenceGenerator(repo port.Repository, opts SequenceOptions) port.ShortCodeGenerator {
+	if opts.Length < 6 || opts.Length > 32 {
+		panic("generator: length should not be less than 6 or greater than 32")
+	}
+	checkAlphabet(opts.Alphabet)
+	if opts.BlockSize < 1 {
+		panic("generator: block size should be greater than 0")
+	}
+	if err := ValidateSequenceKeyspace(opts.Alphabet, opts.Length); err != nil {
+		panic("generator: " + err.Error())
+	}
+	return &sequenceGenerator{repo: repo, opts: opts}
+}
+
+// ValidateSequenceKeyspace returns an error if the codes of length characters
+// of alphabet outnumber the ids a sequence generator encodes
+func ValidateSequenceKeyspace(alphabet string, length int) error {
+	if _, ok := keyspaceSize(len(alphabet), length); !ok {
+		return errors.New("keyspace of length characters should fit in 64 bits")
+	}
+	return nil
+}
+
+// keyspaceSize returns the number of codes of length characters
+func keyspaceSize(alphabetSize int, length int) (uint64, bool) {
+	size := uint64(1)
+	for i := 0; i < length; i++ {
+		hi, lo := bits.Mul64(size, uint64(alphabetSize))
+		if hi != 0 {
+			return 0, false
+		}
+		size = lo
+	}
+	return size, true
+}
+
+// Generate ignores extraLength as sequence codes only collide with user
+// given shortened strings, and the next id is as good as a longer code
+func (g *sequenceGenerator) Generate(ctx context.Context, _ int) (string, error) {
+	id, err := g.nextID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return g.encode(uint64(id))
+}
+
+func (g *sequenceGenerator) nextID(ctx context.Context) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.next == g.end {
+		block, err := g.repo.NextIDBlock(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("generator: repository.NextIDBlock: %w", err)
+		}
+		if block < 0 || block > math.MaxInt64/g.opts.BlockSize-1 {
+			return 0, errSequenceExhausted
+		}
+		g.next = block * g.opts.BlockSize
+		g.end = g.next + g.opts.BlockSize
+	}
+
+	id := g.next
+	g.next++
+	return id, nil
+}
+
+// encode writes id in the alphabet's base using the fewest characters not
+// less than the configured length
+func (g *sequenceGenerator) encode(id uint64) (string, error) {
+	length := g.opts.Length
+	size, _ := keyspaceSize(len(g.opts.Alphabet), length)
+	for id >= size {
+		length++
+		var ok bool
+		if size, ok = keyspaceSize(len(g.opts.Alphabet), length); !ok {
+			return "", errSequenceExhausted
+		}
+	}
+
+	if len(g.opts.Key) > 0 {
+		id = g.permute(id, size)
+	}
+
+	base := uint64(len(g.opts.Alphabet))
+	b := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		b[i] = g.opts.Alphabet[id%base]
+		id /= base
+	}
+	return string(b), nil
+}
+
+// permute maps id to another id less than size, a different one for every
+// id. it runs a feistel network over the smallest even number of bits
+// covering size and walks the cycle until landing back in range
+func (g *sequenceGenerator) permute(id uint64, size uint64) uint64 {
+	half := (bits.Len64(size-1) + 1) / 2
+	for {
+		id = g.feistel(id, half)
+		if id < size {
+			return id
+		}
+	}
+}
+
+const feistelRounds = 4
+
+func (g *sequenceGenerator) feistel(x uint64, half int) uint64 {
+	mask := uint64(1)<<half - 1
+	left, right := x>>half, x&mask
+	for round := 0; round < feistelRounds; round++ {
+		left, right = right, left^(g.roundFunction(round, right)&mask)
+	}
+	return left<<half | right
+}
+
+func (g *sequenceGenerator) roundFunction(round int, x uint64) uint64 {
+	var msg [9]byte
+	msg[0] = byte(round)
+	binary.BigEndian.PutUint64(msg[1:], x)
+	mac := hmac.New(sha256.New, g.opts.Key)
+	mac.Write(msg[:])
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
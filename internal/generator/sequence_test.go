@@ -0,0 +1,190 @@
+package generator_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/generator"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceGenerator(t *testing.T) {
+	require := require.New(t)
+	repo := mockups.NewMockRepository(gomock.NewController(t))
+
+	// a block is reserved every BlockSize codes
+	gomock.InOrder(
+		repo.EXPECT().NextIDBlock(ctx).Return(int64(1), nil),
+		repo.EXPECT().NextIDBlock(ctx).Return(int64(5), nil),
+	)
+
+	g := generator.NewSequenceGenerator(repo, generator.SequenceOptions{
+		Length:    6,
+		Alphabet:  generator.AlphabetBase62,
+		BlockSize: 2,
+	})
+
+	for _, want := range []string{"aaaaac", "aaaaad", "aaaaak", "aaaaal"} {
+		code, err := g.Generate(ctx, 0)
+		require.NoError(err)
+		require.Equal(want, code)
+	}
+}
+
+func TestSequenceGeneratorObfuscates(t *testing.T) {
+	require := require.New(t)
+
+	generate := func(key string, blocks int) []string {
+		repo := mockups.NewMockRepository(gomock.NewController(t))
+		var block int64
+		repo.EXPECT().
+			NextIDBlock(ctx).
+			DoAndReturn(func(_ context.Context) (int64, error) {
+				block++
+				return block - 1, nil
+			}).
+			Times(blocks)
+
+		// 2^6 codes of 6 characters
+		g := generator.NewSequenceGenerator(repo, generator.SequenceOptions{
+			Length:    6,
+			Alphabet:  "ab",
+			BlockSize: 64,
+			Key:       []byte(key),
+		})
+
+		codes := make([]string, 0, blocks*64)
+		for i := 0; i < blocks*64; i++ {
+			code, err := g.Generate(ctx, 0)
+			require.NoError(err)
+			codes = append(codes, code)
+		}
+		return codes
+	}
+
+	codes := generate("key", 2)
+
+	// the first ids permute the whole 6 characters keyspace
+	seen := make(map[string]bool)
+	for _, code := range codes[:64] {
+		require.Len(code, 6)
+		require.False(seen[code])
+		seen[code] = true
+	}
+	require.NotEqual([]string{"aaaaaa", "aaaaab", "aaaaba"}, codes[:3])
+
+	// next ids outgrow it and get a character longer
+	for _, code := range codes[64:] {
+		require.Len(code, 7)
+		require.False(seen[code])
+		seen[code] = true
+	}
+
+	// codes only depend on the key
+	require.Equal(codes, generate("key", 2))
+	require.NotEqual(codes, generate("another_key", 2))
+}
+
+func TestSequenceGeneratorConcurrent(t *testing.T) {
+	require := require.New(t)
+	repo := mockups.NewMockRepository(gomock.NewController(t))
+
+	var block int64
+	repo.EXPECT().
+		NextIDBlock(gomock.Any()).
+		DoAndReturn(func(_ context.Context) (int64, error) {
+			return atomic.AddInt64(&block, 1), nil
+		}).
+		AnyTimes()
+
+	g := generator.NewSequenceGenerator(repo, generator.SequenceOptions{
+		Length:    6,
+		Alphabet:  generator.AlphabetBase62,
+		BlockSize: 3,
+		Key:       []byte("key"),
+	})
+
+	const goroutines, codesPerGoroutine = 10, 50
+	codes := make(chan string, goroutines*codesPerGoroutine)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < codesPerGoroutine; j++ {
+				code, err := g.Generate(ctx, 0)
+				require.NoError(err)
+				codes <- code
+			}
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	seen := make(map[string]bool)
+	for code := range codes {
+		require.False(seen[code])
+		seen[code] = true
+	}
+	require.Len(seen, goroutines*codesPerGoroutine)
+}
+
+func TestSequenceGeneratorNextIDBlockError(t *testing.T) {
+	require := require.New(t)
+	repo := mockups.NewMockRepository(gomock.NewController(t))
+	repo.EXPECT().
+		NextIDBlock(ctx).
+		Return(int64(0), errors.New("NextIDBlock_error"))
+
+	g := generator.NewSequenceGenerator(repo, generator.SequenceOptions{
+		Length:    6,
+		Alphabet:  generator.AlphabetBase62,
+		BlockSize: generator.DefaultSequenceBlockSize,
+	})
+
+	code, err := g.Generate(ctx, 0)
+	require.EqualError(err, "generator: repository.NextIDBlock: NextIDBlock_error")
+	require.Empty(code)
+}
+
+func TestValidateSequenceKeyspace(t *testing.T) {
+	require := require.New(t)
+
+	// 62^10 fits in 64 bits but 62^11 doesn't
+	require.NoError(generator.ValidateSequenceKeyspace(generator.AlphabetBase62, 10))
+	require.EqualError(
+		generator.ValidateSequenceKeyspace(generator.AlphabetBase62, 11),
+		"keyspace of length characters should fit in 64 bits",
+	)
+	require.NoError(generator.ValidateSequenceKeyspace("01", 32))
+}
+
+func TestNewSequenceGeneratorPanics(t *testing.T) {
+	require := require.New(t)
+	repo := mockups.NewMockRepository(gomock.NewController(t))
+
+	require.PanicsWithValue(
+		"generator: block size should be greater than 0",
+		func() {
+			generator.NewSequenceGenerator(repo, generator.SequenceOptions{
+				Length:   6,
+				Alphabet: generator.AlphabetBase62,
+			})
+		},
+	)
+	require.PanicsWithValue(
+		"generator: keyspace of length characters should fit in 64 bits",
+		func() {
+			generator.NewSequenceGenerator(repo, generator.SequenceOptions{
+				Length:    11,
+				Alphabet:  generator.AlphabetBase62,
+				BlockSize: 1,
+			})
+		},
+	)
+}
@@ -0,0 +1,149 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"golang.org/x/crypto/argon2"
+)
+
+var errInvalidArgon2idHash = errors.New("hasher: invalid argon2id hash encoding")
+
+type Argon2idParams struct {
+	Time       uint32
+	Memory     uint32 // in KiB
+	Threads    uint8
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// DefaultArgon2idParams follows the RFC 9106 second recommended option
+var DefaultArgon2idParams = Argon2idParams{
+	Time:       3,
+	Memory:     64 * 1024,
+	Threads:    4,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) port.PasswordHasher {
+	if params.Time < 1 || params.Threads < 1 || params.SaltLength < 8 || params.KeyLength < 16 {
+		panic("hasher: invalid argon2id parameters")
+	}
+	return argon2idHasher{params: params}
+}
+
+func (h argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(
+		[]byte(password),
+		salt,
+		h.params.Time,
+		h.params.Memory,
+		h.params.Threads,
+		h.params.KeyLength,
+	)
+	return encodeArgon2id(h.params, salt, key), nil
+}
+
+func (h argon2idHasher) Compare(hash string, password string) error {
+	return compare(hash, password)
+}
+
+func (h argon2idHasher) NeedsRehash(hash string) bool {
+	if !isArgon2idHash(hash) {
+		return true
+	}
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.Time != h.params.Time ||
+		params.Memory != h.params.Memory ||
+		params.Threads != h.params.Threads ||
+		uint32(len(salt)) != h.params.SaltLength ||
+		uint32(len(key)) != h.params.KeyLength
+}
+
+func compareArgon2id(hash string, password string) error {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return err
+	}
+	otherKey := argon2.IDKey(
+		[]byte(password),
+		salt,
+		params.Time,
+		params.Memory,
+		params.Threads,
+		uint32(len(key)),
+	)
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return domain_errors.ErrIncorrectPassword
+	}
+	return nil
+}
+
+// encodeArgon2id encodes the hash in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+func encodeArgon2id(params Argon2idParams, salt []byte, key []byte) string {
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.Memory,
+		params.Time,
+		params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeArgon2id(
+	hash string,
+) (params Argon2idParams, salt []byte, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil ||
+		version != argon2.Version {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+	if _, err := fmt.Sscanf(
+		parts[3],
+		"m=%d,t=%d,p=%d",
+		&params.Memory,
+		&params.Time,
+		&params.Threads,
+	); err != nil {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidArgon2idHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
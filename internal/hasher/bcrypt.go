@@ -0,0 +1,48 @@
+package hasher
+
+import (
+	"errors"
+
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) port.PasswordHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		panic("hasher: bcrypt cost should not be less than 4 or greater than 31")
+	}
+	return bcryptHasher{cost: cost}
+}
+
+func (h bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h bcryptHasher) Compare(hash string, password string) error {
+	return compare(hash, password)
+}
+
+func (h bcryptHasher) NeedsRehash(hash string) bool {
+	if !isBcryptHash(hash) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+func compareBcrypt(hash string, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return domain_errors.ErrIncorrectPassword
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+)
+
+// compare verifies password against any supported hash encoding, so that
+// switching the configured hasher never locks out existing users
+func compare(hash string, password string) error {
+	switch {
+	case isBcryptHash(hash):
+		return compareBcrypt(hash, password)
+	case isArgon2idHash(hash):
+		return compareArgon2id(hash, password)
+	default:
+		return comparePlainText(hash, password)
+	}
+}
+
+// comparePlainText compares legacy plain text passwords in constant time
+func comparePlainText(stored string, password string) error {
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(password)) != 1 {
+		return domain_errors.ErrIncorrectPassword
+	}
+	return nil
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
+
+func isArgon2idHash(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
@@ -0,0 +1,118 @@
+package hasher_test
+
+import (
+	"testing"
+
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/hasher"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testArgon2idParams = hasher.Argon2idParams{
+	Time:       1,
+	Memory:     1024,
+	Threads:    1,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+func TestHashers(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher port.PasswordHasher
+	}{
+		{
+			name:   "bcrypt",
+			hasher: hasher.NewBcryptHasher(bcrypt.MinCost),
+		},
+		{
+			name:   "argon2id",
+			hasher: hasher.NewArgon2idHasher(testArgon2idParams),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			hash, err := tt.hasher.Hash("password")
+			require.NoError(err)
+			require.NotEqual("password", hash)
+			require.False(tt.hasher.NeedsRehash(hash))
+
+			// hashes are salted
+			otherHash, err := tt.hasher.Hash("password")
+			require.NoError(err)
+			require.NotEqual(hash, otherHash)
+
+			require.NoError(tt.hasher.Compare(hash, "password"))
+			require.ErrorIs(
+				tt.hasher.Compare(hash, "incorrect_password"),
+				domain_errors.ErrIncorrectPassword,
+			)
+
+			// legacy plain text passwords
+			require.True(tt.hasher.NeedsRehash("password"))
+			require.NoError(tt.hasher.Compare("password", "password"))
+			require.ErrorIs(
+				tt.hasher.Compare("password", "incorrect_password"),
+				domain_errors.ErrIncorrectPassword,
+			)
+		})
+	}
+}
+
+func TestCompareAcrossHashers(t *testing.T) {
+	require := require.New(t)
+
+	bcryptHasher := hasher.NewBcryptHasher(bcrypt.MinCost)
+	argon2idHasher := hasher.NewArgon2idHasher(testArgon2idParams)
+
+	bcryptHash, err := bcryptHasher.Hash("password")
+	require.NoError(err)
+	argon2idHash, err := argon2idHasher.Hash("password")
+	require.NoError(err)
+
+	// each hasher verifies the other's hashes but asks for a rehash
+	require.NoError(argon2idHasher.Compare(bcryptHash, "password"))
+	require.True(argon2idHasher.NeedsRehash(bcryptHash))
+	require.NoError(bcryptHasher.Compare(argon2idHash, "password"))
+	require.True(bcryptHasher.NeedsRehash(argon2idHash))
+}
+
+func TestNeedsRehashOnParametersChange(t *testing.T) {
+	require := require.New(t)
+
+	bcryptHash, err := hasher.NewBcryptHasher(bcrypt.MinCost).Hash("password")
+	require.NoError(err)
+	require.True(
+		hasher.NewBcryptHasher(bcrypt.MinCost + 1).NeedsRehash(bcryptHash),
+	)
+
+	argon2idHash, err := hasher.NewArgon2idHasher(testArgon2idParams).
+		Hash("password")
+	require.NoError(err)
+	params := testArgon2idParams
+	params.Time++
+	require.True(hasher.NewArgon2idHasher(params).NeedsRehash(argon2idHash))
+}
+
+func TestInvalidArgon2idHash(t *testing.T) {
+	require := require.New(t)
+
+	h := hasher.NewArgon2idHasher(testArgon2idParams)
+
+	err := h.Compare("$argon2id$v=19$m=1024,t=1,p=1$invalid", "password")
+	require.Error(err)
+	require.NotErrorIs(err, domain_errors.ErrIncorrectPassword)
+	require.True(h.NeedsRehash("$argon2id$v=19$m=1024,t=1,p=1$invalid"))
+}
+
+func TestNewBcryptHasherPanics(t *testing.T) {
+	require.PanicsWithValue(
+		t,
+		"hasher: bcrypt cost should not be less than 4 or greater than 31",
+		func() { hasher.NewBcryptHasher(bcrypt.MinCost - 1) },
+	)
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports whether a component the service depends on is usable
+type Check func(ctx context.Context) error
+
+// ServerComponent names the status of the server itself in readiness reports,
+// which fails once the server is draining
+const ServerComponent = "server"
+
+var ErrDraining = errors.New("health: server is shutting down")
+
+// ErrUnexpectedMigrations is wrapped by the errors of migration checks
+// finding the schema not at the latest migration
+var ErrUnexpectedMigrations = errors.New("migrations at unexpected version")
+
+// Describe returns what's wrong by the error of a failed check without its
+// details, readiness is reported to anyone and errors may hold addresses,
+// dsns or versions
+func Describe(err error) string {
+	switch {
+	case errors.Is(err, ErrDraining):
+		return "shutting down"
+	case errors.Is(err, ErrUnexpectedMigrations):
+		return ErrUnexpectedMigrations.Error()
+	default:
+		return "unreachable"
+	}
+}
+
+// Checker reports the readiness of the service by the checks of the
+// components it depends on
+type Checker struct {
+	timeout  time.Duration
+	mu       sync.Mutex
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+// NewChecker returns a checker bounding every check by timeout
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		panic("health: timeout should be positive")
+	}
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+// Add checks the component name on readiness
+func (c *Checker) Add(name string, check Check) {
+	if name == ServerComponent {
+		panic("health: component name " + ServerComponent + " is reserved")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Drain fails readiness from now on, so load balancers stop routing requests
+// before the server stops accepting them
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready runs the checks concurrently and returns their errors by component
+// name, nil for the healthy ones, and whether every one of them is healthy
+func (c *Checker) Ready(ctx context.Context) (map[string]error, bool) {
+	c.mu.Lock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]error, len(checks)+1)
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			err := check(ctx)
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	results[ServerComponent] = nil
+	if c.draining.Load() {
+		results[ServerComponent] = ErrDraining
+	}
+
+	ready := true
+	for _, err := range results {
+		if err != nil {
+			ready = false
+		}
+	}
+	return results, ready
+}
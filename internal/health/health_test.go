@@ -0,0 +1,132 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	errUnreachable := errors.New("unreachable")
+	checker := health.NewChecker(10 * time.Millisecond)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+
+	results, ready := checker.Ready(ctx)
+	require.True(ready)
+	require.Equal(map[string]error{
+		"database":             nil,
+		health.ServerComponent: nil,
+	}, results)
+
+	// a failing check and a check outliving the timeout
+	checker.Add("cache", func(ctx context.Context) error { return errUnreachable })
+	checker.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	results, ready = checker.Ready(ctx)
+	require.False(ready)
+	require.Equal(map[string]error{
+		"database":             nil,
+		"cache":                errUnreachable,
+		"slow":                 context.DeadlineExceeded,
+		health.ServerComponent: nil,
+	}, results)
+
+	// draining fails readiness even if every component is healthy
+	checker = health.NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+	checker.Drain()
+
+	results, ready = checker.Ready(ctx)
+	require.False(ready)
+	require.Equal(map[string]error{
+		"database":             nil,
+		health.ServerComponent: health.ErrDraining,
+	}, results)
+
+	require.Panics(func() { checker.Add(health.ServerComponent, nil) })
+	require.Panics(func() { health.NewChecker(0) })
+}
+
+func TestDescribe(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("shutting down", health.Describe(health.ErrDraining))
+	require.Equal(
+		"migrations at unexpected version",
+		health.Describe(fmt.Errorf(
+			"%w: at version 1, expected version 2",
+			health.ErrUnexpectedMigrations,
+		)),
+	)
+	require.Equal(
+		"unreachable",
+		health.Describe(errors.New("dial tcp 10.0.0.1:5432: connection refused")),
+	)
+}
+
+func TestMigrations(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	migrations := fstest.MapFS{
+		"migrations/1_add_user.up.sql":   {Data: []byte("")},
+		"migrations/1_add_user.down.sql": {Data: []byte("")},
+		"migrations/2_add_link.up.sql":   {Data: []byte("")},
+		"migrations/2_add_link.down.sql": {Data: []byte("")},
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	check, err := health.Migrations(db, migrations, "migrations")
+	require.NoError(err)
+
+	// no migration table
+	require.Error(check(ctx))
+
+	_, err = db.Exec("CREATE TABLE schema_migrations (version uint64, dirty bool)")
+	require.NoError(err)
+	require.EqualError(
+		check(ctx),
+		"migrations at unexpected version: none applied, expected version 2",
+	)
+
+	_, err = db.Exec("INSERT INTO schema_migrations VALUES (1, false)")
+	require.NoError(err)
+	require.EqualError(
+		check(ctx),
+		"migrations at unexpected version: at version 1, expected version 2",
+	)
+
+	_, err = db.Exec("UPDATE schema_migrations SET version = 2, dirty = true")
+	require.NoError(err)
+	require.EqualError(
+		check(ctx),
+		"migrations at unexpected version: migration 2 is dirty",
+	)
+	require.ErrorIs(check(ctx), health.ErrUnexpectedMigrations)
+
+	_, err = db.Exec("UPDATE schema_migrations SET dirty = false")
+	require.NoError(err)
+	require.NoError(check(ctx))
+
+	// a directory without migrations
+	_, err = health.Migrations(db, migrations, "undefined")
+	require.Error(err)
+}
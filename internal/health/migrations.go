@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Migrations checks the schema of db is migrated to the latest migration of
+// the migrate source files in dir of fsys and is not dirty
+func Migrations(db *sql.DB, fsys fs.FS, dir string) (Check, error) {
+	source, err := iofs.New(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("health: migrations: %w", err)
+	}
+	defer source.Close()
+
+	expected, err := source.First()
+	if err != nil {
+		return nil, fmt.Errorf("health: migrations: %w", err)
+	}
+	for {
+		next, err := source.Next(expected)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("health: migrations: %w", err)
+		}
+		expected = next
+	}
+
+	return func(ctx context.Context) error {
+		var (
+			version uint64
+			dirty   bool
+		)
+		err := db.QueryRowContext(
+			ctx,
+			"SELECT version, dirty FROM schema_migrations",
+		).Scan(&version, &dirty)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf(
+				"%w: none applied, expected version %d",
+				ErrUnexpectedMigrations,
+				expected,
+			)
+		}
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf(
+				"%w: migration %d is dirty",
+				ErrUnexpectedMigrations,
+				version,
+			)
+		}
+		if version != uint64(expected) {
+			return fmt.Errorf(
+				"%w: at version %d, expected version %d",
+				ErrUnexpectedMigrations,
+				version,
+				expected,
+			)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,56 @@
+package httperror
+
+import (
+	"errors"
+	"net/http"
+
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/labstack/echo/v4"
+)
+
+// mapping is the response of a domain error
+type mapping struct {
+	err     error
+	status  int
+	message string
+}
+
+// mappings of domain errors to responses, the first matching one is used.
+// errors needing more than a status and message, like locked links rendering
+// the unlock form, are handled by their operations before reaching here
+var mappings = []mapping{
+	{domain_errors.ErrLinkNotFound, http.StatusNotFound, "link not found"},
+	{domain_errors.ErrLinkNotOwned, http.StatusForbidden, "link is owned by another user"},
+	{domain_errors.ErrLinkExpired, http.StatusGone, "link expired"},
+	{domain_errors.ErrLinkLocked, http.StatusUnauthorized, "link is password protected"},
+	{domain_errors.ErrUserNotFound, http.StatusUnauthorized, "invalid username or password"},
+	{domain_errors.ErrIncorrectPassword, http.StatusUnauthorized, "invalid username or password"},
+	{domain_errors.ErrUsernameTaken, http.StatusConflict, "username have taken"},
+	{domain_errors.ErrUsedShortenedString, http.StatusConflict, "shortened string have used"},
+	{domain_errors.ErrBatchAborted, http.StatusUnprocessableEntity, "not created as another link failed"},
+	{domain_errors.ErrShortCodesExhausted, http.StatusServiceUnavailable, "no unused shortened string generated, retry or give a shortened_string"},
+	{domain_errors.ErrAPIKeyNotFound, http.StatusNotFound, "api key not found"},
+	{domain_errors.ErrInvalidAPIKey, http.StatusUnauthorized, "invalid api key"},
+}
+
+// New returns the response of err, unmapped errors are internal server
+// errors keeping err to be logged but not exposed
+func New(err error) *echo.HTTPError {
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return echo.NewHTTPError(m.status, m.message).SetInternal(err)
+		}
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+}
+
+// Message returns the message of the response of err, unmapped errors are
+// described as failed without exposing err
+func Message(err error, failed string) (string, bool) {
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return m.message, true
+		}
+	}
+	return failed, false
+}
@@ -0,0 +1,77 @@
+package httperror_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    int
+		wantMessage any
+	}{
+		{
+			name: "wrapped domain error",
+			err: fmt.Errorf(
+				"usecase.GetLink: repository.GetLink: %w",
+				domain_errors.ErrLinkNotFound,
+			),
+			wantCode:    http.StatusNotFound,
+			wantMessage: "link not found",
+		},
+		{
+			name:        "first matching mapping",
+			err:         errors.Join(domain_errors.ErrUsernameTaken, domain_errors.ErrLinkExpired),
+			wantCode:    http.StatusGone,
+			wantMessage: "link expired",
+		},
+		{
+			name: "exhausted short codes",
+			err: fmt.Errorf(
+				"usecase.CreateLink: %w after 6 attempts",
+				domain_errors.ErrShortCodesExhausted,
+			),
+			wantCode:    http.StatusServiceUnavailable,
+			wantMessage: "no unused shortened string generated, retry or give a shortened_string",
+		},
+		{
+			name:        "unmapped error",
+			err:         errors.New("unhandled_error"),
+			wantCode:    http.StatusInternalServerError,
+			wantMessage: http.StatusText(http.StatusInternalServerError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			httpError := httperror.New(tt.err)
+
+			require.Equal(tt.wantCode, httpError.Code)
+			require.Equal(tt.wantMessage, httpError.Message)
+			require.Equal(tt.err, httpError.Internal)
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	message, ok := httperror.Message(
+		fmt.Errorf("wrapped: %w", domain_errors.ErrBatchAborted),
+		"failed",
+	)
+	require.True(t, ok)
+	require.Equal(t, "not created as another link failed", message)
+
+	message, ok = httperror.Message(errors.New("unhandled_error"), "failed")
+	require.False(t, ok)
+	require.Equal(t, "failed", message)
+}
@@ -0,0 +1,146 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	"github.com/labstack/echo/v4"
+)
+
+type Options struct {
+	// ShutdownTimeout bounds the whole shutdown, draining connections then
+	// flushing and closing resources
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long readiness fails before the server stops
+	// accepting connections, so load balancers stop routing to it first
+	DrainDelay time.Duration
+}
+
+var DefaultOptions = Options{
+	ShutdownTimeout: 15 * time.Second,
+}
+
+// Lifecycle serves until SIGINT or SIGTERM then shuts the service down,
+// closing the resources registered by OnShutdown once no request is in flight
+type Lifecycle struct {
+	opts    Options
+	health  *health.Checker
+	closers []closer
+}
+
+type closer struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+func New(opts Options, health *health.Checker) *Lifecycle {
+	if opts.ShutdownTimeout <= 0 {
+		panic("lifecycle: shutdown timeout should be positive")
+	}
+	if opts.DrainDelay < 0 {
+		panic("lifecycle: drain delay should not be negative")
+	}
+	return &Lifecycle{
+		opts:   opts,
+		health: health,
+	}
+}
+
+// OnShutdown closes a resource on shutdown. resources are closed in the
+// reverse order they're registered, so a resource may use any registered
+// before it until it's closed
+func (l *Lifecycle) OnShutdown(name string, close func(ctx context.Context) error) {
+	l.closers = append(l.closers, closer{name: name, close: close})
+}
+
+// Serve serves e on listener until ctx is done, a signal is received or the
+// server fails, then shuts down and returns the errors of serving and of
+// shutting down
+func (l *Lifecycle) Serve(
+	ctx context.Context,
+	e *echo.Echo,
+	listener net.Listener,
+) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	e.Listener = listener
+	served := make(chan error, 1)
+	go func() {
+		served <- e.Start("")
+	}()
+
+	var errs []error
+	serving := true
+	select {
+	case err := <-served:
+		serving = false
+		errs = append(errs, fmt.Errorf("lifecycle: serve: %w", err))
+	case <-ctx.Done():
+		// a second signal kills the process
+		stop()
+		log.Print("lifecycle: shutting down")
+		l.health.Drain()
+		time.Sleep(l.opts.DrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(
+		context.Background(),
+		l.opts.ShutdownTimeout,
+	)
+	defer cancel()
+
+	// wait in-flight requests, nothing is recorded by a request after it
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("lifecycle: shutdown server: %w", err))
+	} else if serving {
+		if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs = append(errs, fmt.Errorf("lifecycle: serve: %w", err))
+		}
+	}
+	if err := l.Close(shutdownCtx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the registered resources, it's called by Serve and should
+// only be called otherwise if the service fails to start
+func (l *Lifecycle) Close(ctx context.Context) error {
+	var errs []error
+	for i := len(l.closers) - 1; i >= 0; i-- {
+		closer := l.closers[i]
+		if err := closer.close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("lifecycle: close %s: %w", closer.name, err))
+		}
+	}
+	l.closers = nil
+	return errors.Join(errs...)
+}
+
+// Wait adapts closeFunc, which blocks until it's done, to return once ctx is
+// done even if closeFunc hasn't
+func Wait(closeFunc func()) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			closeFunc()
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
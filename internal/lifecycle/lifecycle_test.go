@@ -0,0 +1,200 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	"github.com/aria3ppp/url-shortener-openapi/internal/lifecycle"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// serve runs app serving a route blocking until release is closed, it
+// returns the route url, a channel closed once a request is in flight and
+// the error of Serve
+func serve(
+	t *testing.T,
+	ctx context.Context,
+	app *lifecycle.Lifecycle,
+	release chan struct{},
+) (string, chan struct{}, chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	inFlight := make(chan struct{})
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.GET("/slow", func(c echo.Context) error {
+		close(inFlight)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	served := make(chan error, 1)
+	go func() {
+		served <- app.Serve(ctx, e, listener)
+	}()
+	return "http://" + listener.Addr().String() + "/slow", inFlight, served
+}
+
+// get requests url in the background
+func get(url string) chan int {
+	status := make(chan int, 1)
+	go func() {
+		response, err := http.Get(url)
+		if err != nil {
+			status <- 0
+			return
+		}
+		response.Body.Close()
+		status <- response.StatusCode
+	}()
+	return status
+}
+
+// closeRecorder records the names of closed resources
+type closeRecorder struct {
+	mu     sync.Mutex
+	closed []string
+}
+
+func (r *closeRecorder) closer(name string, err error) func(context.Context) error {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.closed = append(r.closed, name)
+		return err
+	}
+}
+
+func TestServe(t *testing.T) {
+	require := require.New(t)
+
+	checker := health.NewChecker(time.Second)
+	app := lifecycle.New(lifecycle.Options{
+		ShutdownTimeout: time.Second,
+		DrainDelay:      10 * time.Millisecond,
+	}, checker)
+	recorder := &closeRecorder{}
+	app.OnShutdown("database", recorder.closer("database", nil))
+	app.OnShutdown("click recorder", recorder.closer("click recorder", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release := make(chan struct{})
+	url, inFlight, served := serve(t, ctx, app, release)
+
+	status := get(url)
+	<-inFlight
+	cancel()
+
+	// readiness fails while the request in flight is waited
+	require.Eventually(func() bool {
+		_, ready := checker.Ready(context.Background())
+		return !ready
+	}, time.Second, time.Millisecond)
+	select {
+	case err := <-served:
+		t.Fatalf("served before the request in flight: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	require.Equal(http.StatusOK, <-status)
+	require.NoError(<-served)
+
+	// resources are closed once no request is in flight, in reverse order
+	require.Equal([]string{"click recorder", "database"}, recorder.closed)
+}
+
+func TestServeSignal(t *testing.T) {
+	require := require.New(t)
+
+	app := lifecycle.New(lifecycle.DefaultOptions, health.NewChecker(time.Second))
+	recorder := &closeRecorder{}
+	app.OnShutdown("database", recorder.closer("database", nil))
+
+	release := make(chan struct{})
+	close(release)
+	url, _, served := serve(t, context.Background(), app, release)
+	require.Equal(http.StatusOK, <-get(url))
+
+	require.NoError(syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+	select {
+	case err := <-served:
+		require.NoError(err)
+	case <-time.After(time.Second):
+		t.Fatal("not shut down on SIGTERM")
+	}
+	require.Equal([]string{"database"}, recorder.closed)
+}
+
+func TestServeShutdownTimeout(t *testing.T) {
+	require := require.New(t)
+
+	app := lifecycle.New(lifecycle.Options{
+		ShutdownTimeout: 50 * time.Millisecond,
+	}, health.NewChecker(time.Second))
+	errClose := errors.New("close error")
+	recorder := &closeRecorder{}
+	app.OnShutdown("database", recorder.closer("database", errClose))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	url, inFlight, served := serve(t, ctx, app, release)
+
+	get(url)
+	<-inFlight
+	cancel()
+
+	// resources are closed even if the request in flight outlives the timeout
+	err := <-served
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.ErrorIs(err, errClose)
+	require.ErrorContains(err, "lifecycle: close database: close error")
+	require.Equal([]string{"database"}, recorder.closed)
+}
+
+func TestServeFailure(t *testing.T) {
+	require := require.New(t)
+
+	app := lifecycle.New(lifecycle.DefaultOptions, health.NewChecker(time.Second))
+	recorder := &closeRecorder{}
+	app.OnShutdown("database", recorder.closer("database", nil))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	listener.Close()
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	err = app.Serve(context.Background(), e, listener)
+	require.ErrorContains(err, "lifecycle: serve:")
+	require.Equal([]string{"database"}, recorder.closed)
+}
+
+func TestWait(t *testing.T) {
+	require := require.New(t)
+
+	closed := false
+	require.NoError(lifecycle.Wait(func() { closed = true })(context.Background()))
+	require.True(closed)
+
+	// a close outliving ctx
+	release := make(chan struct{})
+	defer close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := lifecycle.Wait(func() { <-release })(ctx)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,110 @@
+package linkcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// LRU is an in process cache of up to size links, evicting the least
+// recently used one when full
+type LRU struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// most recently used entries are at the front
+	recency *list.List
+}
+
+type lruEntry struct {
+	shortenedString string
+	link            *domain.Link
+	expiresAt       time.Time
+}
+
+var _ port.LinkCache = (*LRU)(nil)
+
+func NewLRU(size int) *LRU {
+	if size < 1 {
+		panic("linkcache: size should be greater than 0")
+	}
+	return &LRU{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		recency: list.New(),
+	}
+}
+
+func (c *LRU) Get(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[shortenedString]
+	if !exists {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !time.Now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false, nil
+	}
+	c.recency.MoveToFront(element)
+	return entry.link, true, nil
+}
+
+func (c *LRU) Set(
+	ctx context.Context,
+	shortenedString string,
+	link *domain.Link,
+	ttl time.Duration,
+) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{
+		shortenedString: shortenedString,
+		link:            link,
+		expiresAt:       time.Now().Add(ttl),
+	}
+	if element, exists := c.entries[shortenedString]; exists {
+		element.Value = entry
+		c.recency.MoveToFront(element)
+		return nil
+	}
+	if c.recency.Len() == c.size {
+		c.remove(c.recency.Back())
+	}
+	c.entries[shortenedString] = c.recency.PushFront(entry)
+	return nil
+}
+
+func (c *LRU) Delete(ctx context.Context, shortenedString string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[shortenedString]; exists {
+		c.remove(element)
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, expired ones included until
+// they're evicted or looked up
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recency.Len()
+}
+
+func (c *LRU) remove(element *list.Element) {
+	c.recency.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).shortenedString)
+}
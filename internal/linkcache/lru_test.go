@@ -0,0 +1,95 @@
+package linkcache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/linkcache"
+	"github.com/stretchr/testify/require"
+)
+
+var ctx = context.Background()
+
+func TestLRU(t *testing.T) {
+	require := require.New(t)
+	cache := linkcache.NewLRU(2)
+
+	link := &domain.Link{ShortenedString: "shortened_string", URL: "url"}
+
+	// miss
+	got, found, err := cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.False(found)
+	require.Nil(got)
+
+	// hit
+	require.NoError(cache.Set(ctx, "shortened_string", link, time.Minute))
+	got, found, err = cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.True(found)
+	require.Equal(link, got)
+
+	// cached absence
+	require.NoError(cache.Set(ctx, "missing", nil, time.Minute))
+	got, found, err = cache.Get(ctx, "missing")
+	require.NoError(err)
+	require.True(found)
+	require.Nil(got)
+
+	// delete
+	require.NoError(cache.Delete(ctx, "shortened_string"))
+	_, found, err = cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.False(found)
+	require.NoError(cache.Delete(ctx, "shortened_string"))
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	require := require.New(t)
+	cache := linkcache.NewLRU(3)
+
+	for i := 0; i < 3; i++ {
+		shortenedString := fmt.Sprintf("shortened_string_%d", i)
+		require.NoError(cache.Set(
+			ctx,
+			shortenedString,
+			&domain.Link{ShortenedString: shortenedString},
+			time.Minute,
+		))
+	}
+	// 0 is used after 1 so 1 is evicted first
+	_, found, _ := cache.Get(ctx, "shortened_string_0")
+	require.True(found)
+
+	require.NoError(cache.Set(ctx, "shortened_string_3", nil, time.Minute))
+	require.Equal(3, cache.Len())
+
+	for i, wantFound := range []bool{true, false, true, true} {
+		_, found, err := cache.Get(ctx, fmt.Sprintf("shortened_string_%d", i))
+		require.NoError(err)
+		require.Equal(wantFound, found, i)
+	}
+
+	// setting a cached link doesn't evict another
+	require.NoError(cache.Set(ctx, "shortened_string_3", nil, time.Minute))
+	require.Equal(3, cache.Len())
+}
+
+func TestLRUExpires(t *testing.T) {
+	require := require.New(t)
+	cache := linkcache.NewLRU(2)
+
+	require.NoError(cache.Set(ctx, "shortened_string", nil, 10*time.Millisecond))
+	_, found, _ := cache.Get(ctx, "shortened_string")
+	require.True(found)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, found, err := cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.False(found)
+	require.Equal(0, cache.Len())
+}
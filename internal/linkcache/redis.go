@@ -0,0 +1,382 @@
+package linkcache
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// Redis caches links in a server speaking the redis protocol, so every
+// instance of the service shares cached links and their invalidations
+type Redis struct {
+	opts RedisOptions
+	// idle connections to reuse
+	idle chan *redisConn
+}
+
+type RedisOptions struct {
+	Addr     string
+	Password string
+	DB       int
+	// KeyPrefix namespaces the keys of links
+	KeyPrefix    string
+	MaxIdleConns int
+	DialTimeout  time.Duration
+	// CommandTimeout bounds commands given a context without a deadline, so
+	// a server which stops replying can't block lookups
+	CommandTimeout time.Duration
+}
+
+var DefaultRedisOptions = RedisOptions{
+	Addr:           "localhost:6379",
+	KeyPrefix:      "link:",
+	MaxIdleConns:   16,
+	DialTimeout:    time.Second,
+	CommandTimeout: time.Second,
+}
+
+// redisError is an error reply of the server, the connection is still usable
+type redisError string
+
+func (e redisError) Error() string { return "linkcache: redis: " + string(e) }
+
+var errRedisProtocol = errors.New("protocol error")
+
+var _ port.LinkCache = (*Redis)(nil)
+
+func NewRedis(opts RedisOptions) *Redis {
+	if opts.MaxIdleConns < 0 {
+		panic("linkcache: max idle conns should not be negative")
+	}
+	if opts.CommandTimeout <= 0 {
+		panic("linkcache: command timeout should be greater than 0")
+	}
+	return &Redis{
+		opts: opts,
+		idle: make(chan *redisConn, opts.MaxIdleConns),
+	}
+}
+
+// redisLink is the cached encoding of a link, unlike domain.Link it keeps
+// the password hash
+type redisLink struct {
+	ShortenedString string               `json:"shortened_string"`
+	URL             string               `json:"url"`
+	Username        string               `json:"username"`
+	CreatedAt       time.Time            `json:"created_at"`
+	ExpiresAt       *time.Time           `json:"expires_at,omitempty"`
+	MaxClicks       *int                 `json:"max_clicks,omitempty"`
+	ClickCount      int                  `json:"click_count"`
+	RedirectType    *domain.RedirectType `json:"redirect_type,omitempty"`
+	Password        string               `json:"password,omitempty"`
+}
+
+func (c *Redis) Get(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, bool, error) {
+	reply, err := c.do(ctx, "GET", c.opts.KeyPrefix+shortenedString)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("linkcache: redis: %w", errRedisProtocol)
+	}
+
+	// null caches the link doesn't exist
+	var cached *redisLink
+	if err := json.Unmarshal(value, &cached); err != nil {
+		return nil, false, fmt.Errorf("linkcache: redis: decode link: %w", err)
+	}
+	if cached == nil {
+		return nil, true, nil
+	}
+	return &domain.Link{
+		ShortenedString: cached.ShortenedString,
+		URL:             cached.URL,
+		Username:        cached.Username,
+		CreatedAt:       cached.CreatedAt,
+		ExpiresAt:       cached.ExpiresAt,
+		MaxClicks:       cached.MaxClicks,
+		ClickCount:      cached.ClickCount,
+		RedirectType:    cached.RedirectType,
+		Password:        cached.Password,
+	}, true, nil
+}
+
+func (c *Redis) Set(
+	ctx context.Context,
+	shortenedString string,
+	link *domain.Link,
+	ttl time.Duration,
+) error {
+	var cached *redisLink
+	if link != nil {
+		cached = &redisLink{
+			ShortenedString: link.ShortenedString,
+			URL:             link.URL,
+			Username:        link.Username,
+			CreatedAt:       link.CreatedAt,
+			ExpiresAt:       link.ExpiresAt,
+			MaxClicks:       link.MaxClicks,
+			ClickCount:      link.ClickCount,
+			RedirectType:    link.RedirectType,
+			Password:        link.Password,
+		}
+	}
+	value, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("linkcache: redis: encode link: %w", err)
+	}
+
+	// PX takes milliseconds and rejects 0
+	milliseconds := ttl.Milliseconds()
+	if milliseconds < 1 {
+		milliseconds = 1
+	}
+	_, err = c.do(
+		ctx,
+		"SET",
+		c.opts.KeyPrefix+shortenedString,
+		string(value),
+		"PX",
+		strconv.FormatInt(milliseconds, 10),
+	)
+	return err
+}
+
+func (c *Redis) Delete(ctx context.Context, shortenedString string) error {
+	_, err := c.do(ctx, "DEL", c.opts.KeyPrefix+shortenedString)
+	return err
+}
+
+// Ping checks the server is reachable
+func (c *Redis) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections, connections in use are closed once
+// their command is done
+func (c *Redis) Close() {
+	for {
+		select {
+		case conn := <-c.idle:
+			conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// do sends a command and returns its reply, which is nil, a string, an int64,
+// a []byte, or an []any of them and of redisError
+func (c *Redis) do(ctx context.Context, args ...string) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	conn, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// the connection is in an unknown state
+		conn.Close()
+		return nil, err
+	}
+	c.release(conn)
+	return reply, err
+}
+
+// conn returns an idle connection or dials a new one
+func (c *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: c.opts.DialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.opts.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("linkcache: redis: dial: %w", err)
+	}
+	conn := &redisConn{
+		conn:    netConn,
+		reader:  bufio.NewReader(netConn),
+		timeout: c.opts.CommandTimeout,
+	}
+
+	if c.opts.Password != "" {
+		if _, err := conn.do(ctx, "AUTH", c.opts.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if c.opts.DB != 0 {
+		if _, err := conn.do(ctx, "SELECT", strconv.Itoa(c.opts.DB)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// release keeps conn to be reused unless there are enough idle connections
+func (c *Redis) release(conn *redisConn) {
+	select {
+	case c.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	// timeout bounds commands of contexts without a deadline
+	timeout time.Duration
+}
+
+func (c *redisConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *redisConn) do(ctx context.Context, args ...string) (any, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("linkcache: redis: %w", err)
+	}
+	// cancelling ctx interrupts the command by expiring the deadline, the
+	// watch is over before the connection is reused
+	if done := ctx.Done(); done != nil {
+		finished := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			select {
+			case <-done:
+				c.conn.SetDeadline(time.Now())
+			case <-finished:
+			}
+		}()
+		defer func() {
+			close(finished)
+			<-stopped
+		}()
+	}
+
+	// commands are sent as an array of bulk strings
+	command := make([]byte, 0, 64)
+	command = append(command, '*')
+	command = strconv.AppendInt(command, int64(len(args)), 10)
+	command = append(command, "\r\n"...)
+	for _, arg := range args {
+		command = append(command, '$')
+		command = strconv.AppendInt(command, int64(len(arg)), 10)
+		command = append(command, "\r\n"...)
+		command = append(command, arg...)
+		command = append(command, "\r\n"...)
+	}
+	if _, err := c.conn.Write(command); err != nil {
+		return nil, fmt.Errorf("linkcache: redis: %w", c.cause(ctx, err))
+	}
+
+	reply, err := readRedisReply(c.reader)
+	if err != nil {
+		var replyErr redisError
+		if errors.As(err, &replyErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("linkcache: redis: %w", c.cause(ctx, err))
+	}
+	return reply, nil
+}
+
+// cause returns the error of ctx if it's done, as it interrupted the command
+// rather than the server, else err
+func (c *redisConn) cause(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func readRedisReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errRedisProtocol
+	}
+	kind, line := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return line, nil
+	case '-':
+		return nil, redisError(line)
+	case ':':
+		n, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return nil, errRedisProtocol
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < -1 {
+			return nil, errRedisProtocol
+		}
+		if n == -1 {
+			return nil, nil
+		}
+		// the value is followed by \r\n
+		value := make([]byte, n+2)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		return value[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < -1 {
+			return nil, errRedisProtocol
+		}
+		if n == -1 {
+			return nil, nil
+		}
+		// error elements are kept so the rest of the array is still read
+		elements := make([]any, n)
+		for i := range elements {
+			elements[i], err = readRedisReply(r)
+			var replyErr redisError
+			if errors.As(err, &replyErr) {
+				elements[i] = replyErr
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		return elements, nil
+	default:
+		return nil, errRedisProtocol
+	}
+}
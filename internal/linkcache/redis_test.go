@@ -0,0 +1,316 @@
+package linkcache_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/linkcache"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis is a local stand-in for a redis server implementing the
+// commands of linkcache.Redis
+type fakeRedis struct {
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	expireAt map[string]time.Time
+	// commands received, arguments joined by spaces
+	commands []string
+	conns    int
+	// silent servers read commands but never reply
+	silent bool
+}
+
+// startFakeRedis returns the address of a fakeRedis requiring password if
+// it's not empty
+func startFakeRedis(t *testing.T, password string) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{
+		password: password,
+		values:   make(map[string]string),
+		expireAt: make(map[string]time.Time),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.conns++
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server, listener.Addr().String()
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+		if s.silent {
+			s.mu.Unlock()
+			continue
+		}
+		var reply string
+		switch {
+		case strings.ToUpper(args[0]) == "AUTH":
+			authenticated = args[1] == s.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		default:
+			reply = s.do(args)
+		}
+		s.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeRedis) do(args []string) string {
+	switch strings.ToUpper(args[0]) {
+	case "SELECT":
+		return "+OK\r\n"
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		value, exists := s.values[args[1]]
+		if !exists || !time.Now().Before(s.expireAt[args[1]]) {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		milliseconds, _ := strconv.Atoi(args[4])
+		s.values[args[1]] = args[2]
+		s.expireAt[args[1]] = time.Now().Add(
+			time.Duration(milliseconds) * time.Millisecond,
+		)
+		return "+OK\r\n"
+	case "DEL":
+		_, exists := s.values[args[1]]
+		delete(s.values, args[1])
+		if exists {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+// readCommand reads an array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		args[i] = string(value[:size])
+	}
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	require := require.New(t)
+	server, addr := startFakeRedis(t, "redis_password")
+
+	opts := linkcache.DefaultRedisOptions
+	opts.Addr = addr
+	opts.Password = "redis_password"
+	opts.DB = 2
+	cache := linkcache.NewRedis(opts)
+	t.Cleanup(cache.Close)
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxClicks := 10
+	redirectType := domain.RedirectMovedPermanently
+	link := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+		CreatedAt:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:       &expiresAt,
+		MaxClicks:       &maxClicks,
+		ClickCount:      3,
+		RedirectType:    &redirectType,
+		Password:        "password_hash",
+	}
+
+	// miss
+	got, found, err := cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.False(found)
+	require.Nil(got)
+
+	// hit keeps every field, the password hash included
+	require.NoError(cache.Set(ctx, "shortened_string", link, time.Minute))
+	got, found, err = cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.True(found)
+	require.Equal(link, got)
+
+	// cached absence
+	require.NoError(cache.Set(ctx, "missing", nil, time.Minute))
+	got, found, err = cache.Get(ctx, "missing")
+	require.NoError(err)
+	require.True(found)
+	require.Nil(got)
+
+	require.NoError(cache.Ping(ctx))
+
+	// delete
+	require.NoError(cache.Delete(ctx, "shortened_string"))
+	_, found, err = cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.False(found)
+
+	// the connection is authenticated and selects the db once then reused
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Equal(1, server.conns)
+	require.Equal("AUTH redis_password", server.commands[0])
+	require.Equal("SELECT 2", server.commands[1])
+	require.Equal("GET link:shortened_string", server.commands[2])
+	require.True(strings.HasPrefix(server.commands[3], "SET link:shortened_string {"))
+	require.True(strings.HasSuffix(server.commands[3], "} PX 60000"))
+	require.Equal("SET link:missing null PX 60000", server.commands[5])
+	require.Equal("PING", server.commands[7])
+	require.Equal("DEL link:shortened_string", server.commands[8])
+}
+
+func TestRedisExpires(t *testing.T) {
+	require := require.New(t)
+	_, addr := startFakeRedis(t, "")
+
+	opts := linkcache.DefaultRedisOptions
+	opts.Addr = addr
+	cache := linkcache.NewRedis(opts)
+	t.Cleanup(cache.Close)
+
+	require.NoError(cache.Set(ctx, "shortened_string", nil, 10*time.Millisecond))
+	_, found, err := cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.True(found)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, found, err = cache.Get(ctx, "shortened_string")
+	require.NoError(err)
+	require.False(found)
+}
+
+func TestRedisErrors(t *testing.T) {
+	require := require.New(t)
+	server, addr := startFakeRedis(t, "redis_password")
+
+	// error replies are returned
+	opts := linkcache.DefaultRedisOptions
+	opts.Addr = addr
+	opts.Password = "incorrect_password"
+	cache := linkcache.NewRedis(opts)
+	t.Cleanup(cache.Close)
+
+	_, _, err := cache.Get(ctx, "shortened_string")
+	require.EqualError(err, "linkcache: redis: WRONGPASS invalid password")
+
+	// a server which can't be reached
+	opts.Addr = "127.0.0.1:1"
+	cache = linkcache.NewRedis(opts)
+	t.Cleanup(cache.Close)
+
+	err = cache.Delete(ctx, "shortened_string")
+	require.ErrorContains(err, "linkcache: redis: dial")
+	require.ErrorContains(cache.Ping(ctx), "linkcache: redis: dial")
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Equal([]string{"AUTH incorrect_password"}, server.commands)
+}
+
+func TestRedisTimeout(t *testing.T) {
+	require := require.New(t)
+	server, addr := startFakeRedis(t, "")
+
+	// a server which accepts connections but never replies
+	server.mu.Lock()
+	server.silent = true
+	server.mu.Unlock()
+
+	opts := linkcache.DefaultRedisOptions
+	opts.Addr = addr
+	opts.CommandTimeout = 50 * time.Millisecond
+	cache := linkcache.NewRedis(opts)
+	t.Cleanup(cache.Close)
+
+	// commands without a deadline are bounded by the command timeout
+	start := time.Now()
+	_, _, err := cache.Get(context.Background(), "shortened_string")
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+	require.Less(time.Since(start), time.Second)
+
+	// cancelling the context interrupts the command
+	opts.CommandTimeout = time.Hour
+	cache = linkcache.NewRedis(opts)
+	t.Cleanup(cache.Close)
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start = time.Now()
+	require.ErrorIs(cache.Ping(cancelCtx), context.Canceled)
+	require.Less(time.Since(start), time.Second)
+}
+
+func TestNewRedisPanics(t *testing.T) {
+	opts := linkcache.DefaultRedisOptions
+	opts.CommandTimeout = 0
+	require.PanicsWithValue(
+		t,
+		"linkcache: command timeout should be greater than 0",
+		func() { linkcache.NewRedis(opts) },
+	)
+}
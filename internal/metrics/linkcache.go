@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// instrumentedLinkCache counts the hits, misses and errors of the wrapped
+// cache, the hit rate is hits over lookups. lookups of links which don't
+// exist are hits if their absence is cached
+type instrumentedLinkCache struct {
+	cache   port.LinkCache
+	metrics *Metrics
+}
+
+func NewLinkCache(cache port.LinkCache, metrics *Metrics) port.LinkCache {
+	return &instrumentedLinkCache{cache: cache, metrics: metrics}
+}
+
+var _ port.LinkCache = (*instrumentedLinkCache)(nil)
+
+// Get counts failed lookups as misses too, as the repository is read then
+func (c *instrumentedLinkCache) Get(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, bool, error) {
+	link, found, err := c.cache.Get(ctx, shortenedString)
+	if err != nil {
+		c.metrics.cacheErrors.Inc()
+	}
+	result := "miss"
+	if err == nil && found {
+		result = "hit"
+	}
+	c.metrics.cacheLookups.WithLabelValues(result).Inc()
+	return link, found, err
+}
+
+func (c *instrumentedLinkCache) Set(
+	ctx context.Context,
+	shortenedString string,
+	link *domain.Link,
+	ttl time.Duration,
+) error {
+	err := c.cache.Set(ctx, shortenedString, link, ttl)
+	if err != nil {
+		c.metrics.cacheErrors.Inc()
+	}
+	return err
+}
+
+func (c *instrumentedLinkCache) Delete(ctx context.Context, shortenedString string) error {
+	err := c.cache.Delete(ctx, shortenedString)
+	if err != nil {
+		c.metrics.cacheErrors.Inc()
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "url_shortener"
+
+// Metrics are the collectors of the service, served in the prometheus text
+// format by Handler. they're collected by Middleware and the decorators
+// returned by NewRepository, NewLinkCache, NewGenerator and NewService
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	redirects          *prometheus.CounterVec
+	linksCreated       prometheus.Counter
+	usersCreated       prometheus.Counter
+	queryDuration      *prometheus.HistogramVec
+	shortCodes         prometheus.Counter
+	shortCodeConflicts prometheus.Counter
+	shortCodeExhausted prometheus.Counter
+	cacheLookups       *prometheus.CounterVec
+	cacheErrors        prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by OpenAPI operation and response status code.",
+		}, []string{"operation", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by OpenAPI operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		redirects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "redirects_total",
+			Help:      "Links followed by redirect status code.",
+		}, []string{"code"}),
+		linksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "links_created_total",
+			Help:      "Links created.",
+		}),
+		usersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "users_created_total",
+			Help:      "Users created.",
+		}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_query_duration_seconds",
+			Help:      "Repository call latency by method.",
+			// queries mostly take a few milliseconds
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 12),
+		}, []string{"method"}),
+		shortCodes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "short_codes_generated_total",
+			Help:      "Shortened strings generated.",
+		}),
+		shortCodeConflicts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "shortened_string_conflicts_total",
+			Help:      "Links not stored as their shortened string, generated or given, is used.",
+		}),
+		shortCodeExhausted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "short_codes_exhausted_total",
+			Help:      "Links not created as every shortened string generated for them is used.",
+		}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "link_cache_lookups_total",
+			Help:      "Link cache lookups by result, hit or miss.",
+		}, []string{"result"}),
+		cacheErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "link_cache_errors_total",
+			Help:      "Failed link cache calls.",
+		}),
+	}
+	m.registry.MustRegister(
+		m.requests,
+		m.requestDuration,
+		m.redirects,
+		m.linksCreated,
+		m.usersCreated,
+		m.queryDuration,
+		m.shortCodes,
+		m.shortCodeConflicts,
+		m.shortCodeExhausted,
+		m.cacheLookups,
+		m.cacheErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// RegisterDB collects the connection pool stats of db labeled by name
+func (m *Metrics) RegisterDB(name string, db *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,176 @@
+package metrics_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/aria3ppp/url-shortener-openapi/internal/metrics"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape returns the metrics m serves
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodGet, "/metrics", nil),
+	)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	require := require.New(t)
+
+	swagger, err := oapi.GetSwagger()
+	require.NoError(err)
+
+	m := metrics.New()
+	e := echo.New()
+	e.Use(metrics.Middleware(m, swagger))
+	e.GET("/link/:shortened_string", func(c echo.Context) error {
+		if c.Param("shortened_string") == "undefined" {
+			return echo.NewHTTPError(http.StatusNotFound, "link not found")
+		}
+		return c.Redirect(http.StatusFound, "http://example.com")
+	})
+	e.GET("/health", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{
+		"/link/shortened_string",
+		"/link/shortened_string",
+		"/link/undefined",
+		"/health",
+		"/undefined/path",
+	} {
+		e.ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, path, nil),
+		)
+	}
+
+	body := scrape(t, m)
+	require.Contains(body, `url_shortener_http_requests_total{code="302",operation="GetLink"} 2`)
+	require.Contains(body, `url_shortener_http_requests_total{code="404",operation="GetLink"} 1`)
+	// routes out of the spec and requests matching no route
+	require.Contains(body, `url_shortener_http_requests_total{code="200",operation="unknown"} 1`)
+	require.Contains(body, `url_shortener_http_requests_total{code="404",operation="unknown"} 1`)
+	require.Contains(body, `url_shortener_http_request_duration_seconds_count{operation="GetLink"} 3`)
+	require.Contains(body, `url_shortener_redirects_total{code="302"} 2`)
+	require.NotContains(body, `url_shortener_redirects_total{code="404"}`)
+}
+
+func TestRepository(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := metrics.New()
+	mock := mockups.NewMockRepository(gomock.NewController(t))
+	repo := metrics.NewRepository(mock, m)
+
+	link := &domain.Link{ShortenedString: "shortened_string", URL: "url"}
+	links := []*domain.Link{link, link}
+	user := &domain.User{Username: "username", Password: "password"}
+
+	mock.EXPECT().CreateLink(ctx, link).Return(nil)
+	mock.EXPECT().CreateLink(ctx, link).Return(domain_errors.ErrUsedShortenedString)
+	mock.EXPECT().CreateLinks(ctx, links).Return(nil, nil)
+	mock.EXPECT().CreateLinks(ctx, links).Return([]int{1}, nil)
+	mock.EXPECT().CreateUser(ctx, user).Return(nil)
+	mock.EXPECT().CreateUser(ctx, user).Return(domain_errors.ErrUsernameTaken)
+	mock.EXPECT().GetLink(ctx, "shortened_string").Return(link, nil)
+
+	require.NoError(repo.CreateLink(ctx, link))
+	require.Error(repo.CreateLink(ctx, link))
+	_, err := repo.CreateLinks(ctx, links)
+	require.NoError(err)
+	_, err = repo.CreateLinks(ctx, links)
+	require.NoError(err)
+	require.NoError(repo.CreateUser(ctx, user))
+	require.Error(repo.CreateUser(ctx, user))
+	_, err = repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+
+	// only created links and users are counted
+	body := scrape(t, m)
+	require.Contains(body, "url_shortener_links_created_total 3")
+	require.Contains(body, "url_shortener_users_created_total 1")
+	require.Contains(body, "url_shortener_shortened_string_conflicts_total 2")
+	require.Contains(body, `url_shortener_repository_query_duration_seconds_count{method="CreateLink"} 2`)
+	require.Contains(body, `url_shortener_repository_query_duration_seconds_count{method="CreateLinks"} 2`)
+	require.Contains(body, `url_shortener_repository_query_duration_seconds_count{method="GetLink"} 1`)
+}
+
+func TestShortCodes(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := metrics.New()
+	controller := gomock.NewController(t)
+	repository := mockups.NewMockRepository(controller)
+	generator := mockups.NewMockShortCodeGenerator(controller)
+	service := metrics.NewService(
+		usecase.NewService(
+			metrics.NewRepository(repository, m),
+			metrics.NewGenerator(generator, m),
+			mockups.NewMockPasswordHasher(controller),
+		),
+		m,
+	)
+
+	// first link collides twice, second link collides on every attempt
+	generator.EXPECT().
+		Generate(ctx, gomock.Any()).
+		Return("random_shortened_string", nil).
+		Times(3 + 6)
+	gomock.InOrder(
+		repository.EXPECT().
+			CreateLink(ctx, gomock.Any()).
+			Return(domain_errors.ErrUsedShortenedString).
+			Times(2),
+		repository.EXPECT().
+			CreateLink(ctx, gomock.Any()).
+			Return(nil),
+		repository.EXPECT().
+			CreateLink(ctx, gomock.Any()).
+			Return(domain_errors.ErrUsedShortenedString).
+			Times(6),
+	)
+
+	user := &domain.User{Username: "username"}
+	_, err := service.CreateLink(ctx, &domain.Link{URL: "url"}, user)
+	require.NoError(err)
+	_, err = service.CreateLink(ctx, &domain.Link{URL: "url"}, user)
+	require.ErrorIs(err, domain_errors.ErrShortCodesExhausted)
+
+	body := scrape(t, m)
+	require.Contains(body, "url_shortener_short_codes_generated_total 9")
+	require.Contains(body, "url_shortener_shortened_string_conflicts_total 8")
+	require.Contains(body, "url_shortener_short_codes_exhausted_total 1")
+}
+
+func TestRegisterDB(t *testing.T) {
+	require := require.New(t)
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(err)
+	t.Cleanup(func() { db.Close() })
+
+	m := metrics.New()
+	require.NoError(m.RegisterDB("sqlite", db))
+
+	require.Contains(scrape(t, m), `go_sql_open_connections{db_name="sqlite"}`)
+}
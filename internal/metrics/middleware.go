@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// routes following links, their redirects are counted
+var redirectRoutes = map[string]bool{
+	"GET /link/:shortened_string":  true,
+	"POST /link/:shortened_string": true,
+}
+
+// unknownOperation labels requests of routes the spec doesn't define
+const unknownOperation = "unknown"
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Middleware collects the requests served by the echo routes of the
+// operations of swagger. it should be used before any middleware which may
+// respond an error so their responses are counted too
+func Middleware(m *Metrics, swagger *openapi3.T) echo.MiddlewareFunc {
+	// operation ids by method and echo route path. the spec embedded by
+	// oapi-codegen has the ids as the names of the generated handlers
+	operations := make(map[string]string)
+	for path, pathItem := range swagger.Paths {
+		route := pathParam.ReplaceAllString(path, ":$1")
+		for method, operation := range pathItem.Operations() {
+			operations[method+" "+route] = operation.OperationID
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			if err := next(c); err != nil {
+				// respond the error now so its status code is known
+				c.Error(err)
+			}
+
+			route := c.Request().Method + " " + c.Path()
+			operation, exists := operations[route]
+			if !exists {
+				operation = unknownOperation
+			}
+			status := c.Response().Status
+			code := strconv.Itoa(status)
+
+			m.requests.WithLabelValues(operation, code).Inc()
+			m.requestDuration.WithLabelValues(operation).
+				Observe(time.Since(start).Seconds())
+			if redirectRoutes[route] && status >= 300 && status < 400 {
+				m.redirects.WithLabelValues(code).Inc()
+			}
+			return nil
+		}
+	}
+}
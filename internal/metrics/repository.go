@@ -0,0 +1,217 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// instrumentedRepository collects the latency of every call to the wrapped
+// repository and counts the links and users it creates and the links it
+// rejects for their used shortened strings
+type instrumentedRepository struct {
+	repo    port.Repository
+	metrics *Metrics
+}
+
+func NewRepository(repo port.Repository, metrics *Metrics) port.Repository {
+	return &instrumentedRepository{repo: repo, metrics: metrics}
+}
+
+var _ port.Repository = (*instrumentedRepository)(nil)
+
+func (m *Metrics) observeQuery(method string, start time.Time) {
+	m.queryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func (r *instrumentedRepository) GetLink(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, error) {
+	defer r.metrics.observeQuery("GetLink", time.Now())
+	return r.repo.GetLink(ctx, shortenedString)
+}
+
+func (r *instrumentedRepository) RegisterLinkClick(
+	ctx context.Context,
+	shortenedString string,
+	now time.Time,
+) (*domain.Link, error) {
+	defer r.metrics.observeQuery("RegisterLinkClick", time.Now())
+	return r.repo.RegisterLinkClick(ctx, shortenedString, now)
+}
+
+func (r *instrumentedRepository) CreateLink(ctx context.Context, link *domain.Link) error {
+	defer r.metrics.observeQuery("CreateLink", time.Now())
+	err := r.repo.CreateLink(ctx, link)
+	if err == nil {
+		r.metrics.linksCreated.Inc()
+	} else if errors.Is(err, domain_errors.ErrUsedShortenedString) {
+		r.metrics.shortCodeConflicts.Inc()
+	}
+	return err
+}
+
+func (r *instrumentedRepository) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+) ([]int, error) {
+	defer r.metrics.observeQuery("CreateLinks", time.Now())
+	used, err := r.repo.CreateLinks(ctx, links)
+	if err == nil && len(used) == 0 {
+		r.metrics.linksCreated.Add(float64(len(links)))
+	}
+	r.metrics.shortCodeConflicts.Add(float64(len(used)))
+	return used, err
+}
+
+func (r *instrumentedRepository) UpdateLink(ctx context.Context, link *domain.Link) error {
+	defer r.metrics.observeQuery("UpdateLink", time.Now())
+	return r.repo.UpdateLink(ctx, link)
+}
+
+func (r *instrumentedRepository) DeleteLink(ctx context.Context, shortenedString string) error {
+	defer r.metrics.observeQuery("DeleteLink", time.Now())
+	return r.repo.DeleteLink(ctx, shortenedString)
+}
+
+func (r *instrumentedRepository) ListUserLinks(
+	ctx context.Context,
+	username string,
+	offset int,
+	limit int,
+) ([]*domain.Link, error) {
+	defer r.metrics.observeQuery("ListUserLinks", time.Now())
+	return r.repo.ListUserLinks(ctx, username, offset, limit)
+}
+
+func (r *instrumentedRepository) ListUserLinksAfter(
+	ctx context.Context,
+	username string,
+	after *domain.Link,
+	limit int,
+) ([]*domain.Link, error) {
+	defer r.metrics.observeQuery("ListUserLinksAfter", time.Now())
+	return r.repo.ListUserLinksAfter(ctx, username, after, limit)
+}
+
+func (r *instrumentedRepository) CountUserLinks(ctx context.Context, username string) (int, error) {
+	defer r.metrics.observeQuery("CountUserLinks", time.Now())
+	return r.repo.CountUserLinks(ctx, username)
+}
+
+func (r *instrumentedRepository) GetUser(ctx context.Context, username string) (*domain.User, error) {
+	defer r.metrics.observeQuery("GetUser", time.Now())
+	return r.repo.GetUser(ctx, username)
+}
+
+func (r *instrumentedRepository) CreateUser(ctx context.Context, user *domain.User) error {
+	defer r.metrics.observeQuery("CreateUser", time.Now())
+	err := r.repo.CreateUser(ctx, user)
+	if err == nil {
+		r.metrics.usersCreated.Inc()
+	}
+	return err
+}
+
+func (r *instrumentedRepository) UpdateUserPassword(
+	ctx context.Context,
+	username string,
+	password string,
+) error {
+	defer r.metrics.observeQuery("UpdateUserPassword", time.Now())
+	return r.repo.UpdateUserPassword(ctx, username, password)
+}
+
+func (r *instrumentedRepository) GetAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	defer r.metrics.observeQuery("GetAPIKey", time.Now())
+	return r.repo.GetAPIKey(ctx, prefix)
+}
+
+func (r *instrumentedRepository) ListAPIKeys(
+	ctx context.Context,
+	username string,
+) ([]*domain.APIKey, error) {
+	defer r.metrics.observeQuery("ListAPIKeys", time.Now())
+	return r.repo.ListAPIKeys(ctx, username)
+}
+
+func (r *instrumentedRepository) CreateAPIKey(ctx context.Context, apiKey *domain.APIKey) error {
+	defer r.metrics.observeQuery("CreateAPIKey", time.Now())
+	return r.repo.CreateAPIKey(ctx, apiKey)
+}
+
+func (r *instrumentedRepository) DeleteAPIKey(
+	ctx context.Context,
+	username string,
+	prefix string,
+) error {
+	defer r.metrics.observeQuery("DeleteAPIKey", time.Now())
+	return r.repo.DeleteAPIKey(ctx, username, prefix)
+}
+
+func (r *instrumentedRepository) UpdateAPIKeyLastUsedAt(
+	ctx context.Context,
+	prefix string,
+	lastUsedAt time.Time,
+) error {
+	defer r.metrics.observeQuery("UpdateAPIKeyLastUsedAt", time.Now())
+	return r.repo.UpdateAPIKeyLastUsedAt(ctx, prefix, lastUsedAt)
+}
+
+func (r *instrumentedRepository) NextIDBlock(ctx context.Context) (int64, error) {
+	defer r.metrics.observeQuery("NextIDBlock", time.Now())
+	return r.repo.NextIDBlock(ctx)
+}
+
+func (r *instrumentedRepository) CreateClicks(ctx context.Context, clicks []*domain.Click) error {
+	defer r.metrics.observeQuery("CreateClicks", time.Now())
+	return r.repo.CreateClicks(ctx, clicks)
+}
+
+func (r *instrumentedRepository) CountClicks(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+) (int, int, error) {
+	defer r.metrics.observeQuery("CountClicks", time.Now())
+	return r.repo.CountClicks(ctx, shortenedString, from, to)
+}
+
+func (r *instrumentedRepository) ListClickBuckets(
+	ctx context.Context,
+	shortenedString string,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) ([]*domain.StatsBucket, error) {
+	defer r.metrics.observeQuery("ListClickBuckets", time.Now())
+	return r.repo.ListClickBuckets(ctx, shortenedString, interval, from, to)
+}
+
+func (r *instrumentedRepository) ListTopReferrers(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	defer r.metrics.observeQuery("ListTopReferrers", time.Now())
+	return r.repo.ListTopReferrers(ctx, shortenedString, from, to, limit)
+}
+
+func (r *instrumentedRepository) ListTopUserAgentFamilies(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	defer r.metrics.observeQuery("ListTopUserAgentFamilies", time.Now())
+	return r.repo.ListTopUserAgentFamilies(ctx, shortenedString, from, to, limit)
+}
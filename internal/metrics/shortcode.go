@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// instrumentedGenerator counts the short codes the wrapped generator
+// generates, the conflict rate is conflicts over generated with the
+// conflicts counted by the repository returned by NewRepository
+type instrumentedGenerator struct {
+	generator port.ShortCodeGenerator
+	metrics   *Metrics
+}
+
+func NewGenerator(
+	generator port.ShortCodeGenerator,
+	metrics *Metrics,
+) port.ShortCodeGenerator {
+	return &instrumentedGenerator{generator: generator, metrics: metrics}
+}
+
+var _ port.ShortCodeGenerator = (*instrumentedGenerator)(nil)
+
+func (g *instrumentedGenerator) Generate(
+	ctx context.Context,
+	extraLength int,
+) (string, error) {
+	shortCode, err := g.generator.Generate(ctx, extraLength)
+	if err == nil {
+		g.metrics.shortCodes.Inc()
+	}
+	return shortCode, err
+}
+
+// instrumentedService counts the links the wrapped service doesn't create
+// as every generated short code is used. methods which don't create links
+// are the wrapped service's
+type instrumentedService struct {
+	port.ServiceUseCases
+	metrics *Metrics
+}
+
+func NewService(service port.ServiceUseCases, metrics *Metrics) port.ServiceUseCases {
+	return &instrumentedService{ServiceUseCases: service, metrics: metrics}
+}
+
+var _ port.ServiceUseCases = (*instrumentedService)(nil)
+
+func (s *instrumentedService) CreateLink(
+	ctx context.Context,
+	link *domain.Link,
+	user *domain.User,
+) (*domain.Link, error) {
+	newLink, err := s.ServiceUseCases.CreateLink(ctx, link, user)
+	if errors.Is(err, domain_errors.ErrShortCodesExhausted) {
+		s.metrics.shortCodeExhausted.Inc()
+	}
+	return newLink, err
+}
+
+func (s *instrumentedService) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+	user *domain.User,
+	atomic bool,
+) ([]*domain.LinkResult, error) {
+	results, err := s.ServiceUseCases.CreateLinks(ctx, links, user, atomic)
+	for _, result := range results {
+		if errors.Is(result.Err, domain_errors.ErrShortCodesExhausted) {
+			s.metrics.shortCodeExhausted.Inc()
+		}
+	}
+	return results, err
+}
@@ -18,19 +18,69 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xW32/bOAz+VwJeH+5wSu1ee0Xqt7tDURxWYEO3PWxBVqg2E6u1JZVSumSB//eBcn44",
-	"idMOQdFie4ojiRS/j+RHzSA1pTUatXeQzMBKkiV6pPDP5YY8asyunSelR7ymNCRgpc9BgJYlQrJ9TADh",
-	"/VgRZpB4GqMAl+ZYSrYvlb5EPfI5JKeCHXkkdvmlL7vf/ul+jrtngz8PQICf2uC8dllVVe0Vnf/XZApD",
-	"gP8RSo+XSt9dLbemvJEa7VF7/pTWFiqVXhkd3TqjA7BlOJaMRfJzf22A9w1YwJgKdjA0VEoPCYxJteBq",
-	"ktUPNoPlIXNzi6ln8JWYg/3okJ4HrJXOfTWUrcW4XBRQyskC+Eksmjz02sA6pLoeZo9btjJ43Z7zDW4W",
-	"V4hVmK1cBTtnjXbbZVIv/3x18iIMt3QyB9e4u41vARm6lJRl7iCB84ksbYGdRQ44+HMiQ8/EPrIv/tii",
-	"qETn5Ahb9jaALg7uC+cCPZdT3YzPAuol+2c/1JUAh+mYlJ++ZxDrcV835SSAZP830ql0FVjuva0vU3po",
-	"QpqULzDUfNFdVB91peUOeEBydTBHhzGzbixq3krg+DA+jIMO+DyEERVK3wVWjQvcM7eB+f8zSBoKAM0x",
-	"EhJ2QDiEBH6LVqMwWps0UfuY2VSZv+J4t7v5uWiHFFUCTuKjp8232yhYnu1p+fePhNxi2SgFSPqtRdAf",
-	"VAM+FxITzTaVpeJ7R9iSqnlrwQa7x3GPf9aL9B1SKTnezhVmijD1nd9xYpEUlqi9LP4AATnKbP6guTR1",
-	"N6434hMDugoUn7wCxeOylDSFBD6ZMXUuzj90UGfWKO3Zc/Ot1m+/YXUk2pL2avBIdiLO6FMpYvWDfZpg",
-	"l3r+ykQvCH1Mn5aE7qVPmy/DHfq03kFv37ySglS1iNDDgtbwCgoTIomiwqSyyI3zSS/uxVANBEy6zhtb",
-	"qFEe+FNM233qj6Q9Hg5P9S0Plu8BAAD//5ArGFvPDAAA",
+	"H4sIAAAAAAAC/+xc+5PbtvH/VzD8ZuY7nVKPezRx9Jt9cdJrnNpjOzNtrasGR66O8JEADYCnUzzXv72z",
+	"AEiRIqgHJd8laX6TRBC7WHz2iYU+B5HIcsGBaxVMPgc5lTQDDdJ8ozmb3cJylkuYs3v8hfFgEuRUJ0EY",
+	"cJpBMFkfFAYSPhVMQhxMtCwgDFSUQEbt7FqDxCn+/YEOfnk++Nd48O3Vn78KwkAvc5xMacn4TfDwEAYp",
+	"47eznCq1EDLGt2NQkWS5ZsIyYZ8QMSeUVN9yKTREGmKC7weh5TgBGoNc8fyPwSvGbwdvytnrTM6FzKiu",
+	"UejkTs3mLIVZ+YKTzqcC5HJFyj2tU4hhTosUSUTqLggD4EUWTD64bx+V4MGVj6ZKhNTAIZ65H/0b0hq2",
+	"aUsyxl8Bv9FJMPk69G/QcPafgX+THuzMoPQLETMwmLmQQDU8f3P5IyzfVg+X+CgSXAPXFll5yiKKezky",
+	"C558ruNEihykdjPCfc4kqJmVcbU9MdUw0CyDNmOlKD4HGb0vl3c+DuurPfFJeCWnD3aK1T6I648QaVz0",
+	"Q+gWiRj6EktsAl0nYLBMlBa5IhJiJiHSjN8QqolOmCJOCruJJqP3syhl0a3aj9Zcg7TkMsqXxE1hZMqy",
+	"IqtLlHENNyCRWrcCV7QET5cVKUW0IItEkFsuFsrSqymiTzm79/iZZ/kloZl98jn4SsI8mAT/N1pZwpHd",
+	"JzV66wa/x7EdGriDBnm1JwwKmTYQXUgWbEMlvrMVlOoF1VFyHGhSLTIWNczWnKYKwrXtjAx1Ancgl25f",
+	"JeGC1zB4LUQKlAel+TT2S0Omtm2DWQ4u7FJD5jB8aV88GY/tnpffK2pUSrpsyc8S3ijBnxXI48iuDv7D",
+	"kVsokNvt2rMODM66/GwDXSWJmuJ2yOoyy4XUBm3dwsqKVLOcSj3C5Q9iqukmeaE7bcjqmnFqnOlmts17",
+	"HXz+zFMR3e5uqu8Hi8ViYNgtZAo8EjHEh2zyZta3ifnnPD6qp2mZP5qmr+fB5MM+hvBqXflvIdeEzQm9",
+	"VsB1SHiRpkSCArTnpZ3XwnxWIO9AktKahAEOptcp2ODk2HbRjFO54MoXn9gHh5pIGwFvs2KWJmqyG9z2",
+	"hzRn5BaWREEkQZNFwqKEMFW6SF1IDjERPIKtAkEaYcWaTzTrm/jynmZ5CqQUV7AW6BxFVn2CuWbE0h1j",
+	"zKrgvzau5nSewvV3Bs87wvxRzL4nY0DmQp8/qAn5cFCVgcpRoGUjEE+kyYvsGiQmisb7k3KgL16VoIpU",
+	"9whN3poXg4dqUn/8Uc4fVuz2leJLKYU8llbiXDWlqekeKEVvwPNsbWHlwL7L+QFMMGGDr6Ms6jHjpb6r",
+	"/ivQVPdXgE3ItFPvykgjnPvtqqNdxluxeHR1RNG9EtEtxAfIbKO2rbMVBhru9SjRWdqc01OmafL/vgzJ",
+	"mPJVziiPTaRWPcKcnymFZQAhSSz4/+spz9DyDckLKRYKpCI3oAnlpDDxNkGvNizl8kXwjRPvsznvNNXq",
+	"i3FiZt+dHaVtNKiOG4LuriirYHSjflTz9lcLdVSrsl/hwGJkfY1o8RtKVo8lQc66n2qhaep75K0yODq1",
+	"ScsZ+kqzDErbRrV8UmZZhUzRwpbJVxC6GrjNgWiUwOBCcC3FFtsRBq+E3ZrmuC3JGb74XoifKF8+1xqy",
+	"XKsDNv2xzKIQtrY5pyyFeGX/aLmEhhTfgpbLwfO5BtneDwWR4LEiBdcsNdvA4V6XM6FFpWkqFtA8e2jB",
+	"ynBpnxuaTm27nO1eaVWfVCylSs8KtSelMgprPVgdLG2pkpRnSy4ZqS23rUph0KwXtoT1a63i1hh34cum",
+	"oKqd31ZRfBOKi2S5KsIwRbjQ7ZhrtR6fdNqVimoUsaPqxgYrQRsI9BXYphgtDC5K6+/i3j1yHKWpLtRu",
+	"8fQ7O3adPzeFj7EufpoHrzSOGYqXpm8aozZxtL7kBw/1460trHPcvdB3FcHyWFOg90GTiuK+8gBiPWh/",
+	"CtRLsdiUqeBMUiwI4+YjFpyJ0lSWx3AnoSUg0N7zGzMoUnekOnhuxxJfWM/WEx2x2KJAJlZqix7rbjOz",
+	"Ln9I9Fiep1kE3O3Q8fdfEDy8rlfbv7Cx2zuW/GrJledAR4ps9y3GHZR3NK0bj0QUMgiDmC6DMFgA3Hot",
+	"iALJYPe0wHD7oohuQfuyAy1251mLfCZhDlKC3JOBCyNmL/0cYyw5ozele2gaBnxIzEMypxlLGSiiiigh",
+	"VJGLRIoMQvI9kzAX95iwvxC4m0dhTNN0Yym+4OxTAbM7ppgWUm0yqTFTmvFIYycBroTlJKEqAeWxluvn",
+	"fQgrs0810Kyx1+algsn6rrUl7oN5Q/9b60q0zon1lASPDCun4d5atfmcjU/Cs/FpeDb+JjwbP7vyGbA6",
+	"QP0muUP+xiftjl7Pdm0RvSUQBp1S9omuhqr2arpdyx1Nix2snB0WupmufBmhgqiQTC/fIcxbh3YdLWLP",
+	"31wOfoTa6TPNmauZlPaz0Z9mdMh4GKpYtHoNoWHTTcbnxrRoplMwBj4dlDZaDmjOgjC4A6kspE6GY6Ql",
+	"cuD4aBKcDcfDsbHLOjFrGCUm5PoFP9+AtyqQC+nOYHMpIlDKpp7sDoaBmVuaFPwyDia2BH8HHBRubOPU",
+	"9HQ87jId1biRp5htE9hRWsYWQhkum3RX50FBvaFs2U2x1nM28vdiPfRZQMdp50MYnI9Ptr/ePpExb37b",
+	"882/jMe93zzr9WZNU0xHgAfmH64ewpryfLh6uKp2ePR5PeB4sJBMQXtM5nfmd0JNLDsklpVYkfPxOUa2",
+	"VZQbC7DhO9wzpU11+nx8hkOYnnLzoyLXhSmqiAWGytdLQrnQCUiCS2gj3ZKuENfAyXmb078LcuEqVwdi",
+	"4az3m+ePjKKeWAi7DJG3PGn3niyYTgjTq4ZDgqYzxNhl1TEy5a5lxO48Bgumv2xI3oDMKC6p1kVIJZAI",
+	"y5zYWbKqwk25IegSkBBPK4xNtNNl9N71NSLpVVdxaI9EAPNUKpdT3qTDARtaDLV4OOVT/sbfjVxbn2no",
+	"cCBfa0Z2OaM7YEEmDZIXTAGer2Bds37IgqiXwGOQEA/J97ZyWRYspxz5k1SjKmUMGclBWqFTbr/Y8KvD",
+	"GxgFqTeFd/QJrYaMmn3b2CvUULCzXbSnhAsC+Gx8uu8L3+z7wrP9XtjJAtQOBA9S4POTvm7g/HQH17Ne",
+	"pT/QZBRZRuUymAT/FIUkP7x8T4DHuWDWdO6HpFb6imDKUSvaBsb2ybVti1Xdlm1B3bvBWKfpd6Z8V8dD",
+	"NvudKfc7nlU7X59Qx98M2CvU8Qc5vZH2h0vc5BLLsLcJ2XfFdca0hWzNnm+6z4JorfXJM7TwCHfjQM/G",
+	"Z+QdAHltAh8H5GoijzfB586FTHnpQwi9oYx7kFv10fZCrrcL96HlG87+MMTHNsRW9N2YCjbF76Ncwh2D",
+	"xYYME2FTBSZ4yo016wWWqhcJyDK+JzcCwYc3O1zFEY0o5fGULxLgoYUwRXuqBUdwzwUekBKmh+SNZQLn",
+	"rGyyyfYJtfFaG67uFX+IP147i+57YPz6x6dC1FGQ4WTkdugo/nkTlFRZI/YC6QL3kVBO06VmkaplB+7M",
+	"5QNW/UKixZ+IpPwGyLWpjxm3O+VlGbBnHml/U1O+Xx7pYmRb/W6Jr7lAl70Yw/0NielSkWuYCwnE1TE9",
+	"dwdtndPTdbGhqvcQbqLMxaKDmBa9SPmmqtVkfbcebTF/nwr/w5Vfh7cb/nbX1x+BzhcMdL6k/UAuaubD",
+	"q4nYPtyrdtnVfvxk8v/CiVQp6G6DjJ17rmxRWUNzR6XQCXCNvVsQG7sYEg4LUJrMmVSeSkLVA9g2kT7r",
+	"4TrlPJbjZPNt0y5zVGu/80x6OjaHy25Wd5uwm0ZPS+TrgnwiS/TIVqFC2gjucyF1J+BemseNG6R74Y68",
+	"x/peuQT04kpLoBnWxS7UHbHkFUnoHUaltsxmujowFIgoJ9cw5cw0oyBZGt2GBO4jyDWmSY10ysQna9W9",
+	"NvDtijqgv0MZrfkHAw9XWwPZzU2VB7TMll2V+BcFe8fIzd5Ty+3gO6ZyoVi7s9TXSvo/pSYWgfXjsqZE",
+	"bbsUKXKM5/D+89iZaQwXTQt+ngoaQ2zblMS8HPo1+Ym9GJKXqGBTbtqZFIkSwNSYpOwWXGo2s4UzB/1V",
+	"sw7J6JJcQxmQ51TpKVfCKRbEbmhZ7LbrcHpkVMwlfoKbgpxY8HDKbwFyTOzwl1azU6NKZ0JYU88GRy+3",
+	"+jvljiMh41W7Fi7eVONR9/GLIhwgbmo+p5lr12KSRCItMq4mpoKIDIvctuSlyylf96FhTTAhWXUnhaTR",
+	"VWTmKXd+OOW2OOMomdWwGy5M7f5vSnDHaCJSvIZBjPbhguxZtjJZ8pTj8hTNcI2Qxh7Dc5kd3/DsWfHp",
+	"uB/eq1jZdTnpqUL5k7PfmE2ZXJdVc79JsQffNZPiVJhqc9k4JECjpGUiGlrpV0Kjvc49oi5ezon9Q4mQ",
+	"ADOqUHP3TK1MhP3viLC6i1Rz61N+fnpqbpu7Vn01JK+roqbjdG26yuKUEzYDhdPxGBnhOKsSqFiUpUPy",
+	"nDhMu+oUXxLG72jK4pICTvPRxgBmyPl4XC5sQZdtxVy/+npYw0P7fz4ObHvw3Md9Kh07PT2c8SdQNwk0",
+	"Xm5vyFkkYEBSHm+zCEwEip+hRJ0Ky9jXNZaJuYV3tQ6sXMWQA5a7BB+SS20VwmhtY3LTt4UettDa1lEX",
+	"3FvPegs0ZkdtAdqxH6W7e6hM/Dd1D1WJfy9lWv/Llw418ld/H7u/ZyWUEc3ZoLz25y2LmJuGpm2t3352",
+	"3VT8FUfW9QM3H1KsOPpjpf3XZgcYXc//kPwu052rNmZHn5v/HLjWK9bcurdwJ27rW/d4LVvnv16U7xXX",
+	"N6Ud2C2xrVX2fXP9wHSrTkajVEQ0TYTSk2fjZ2OTAtwPlBZ5ym4So1sM9+VTpE9ofjaff80/YpPrfwcA",
+	"WZqfR1BSAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -14,17 +14,59 @@ import (
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /healthz)
+	GetLiveness(ctx echo.Context) error
+
 	// (POST /link)
 	CreateLink(ctx echo.Context) error
+
+	// (DELETE /link/{shortened_string})
+	DeleteLink(ctx echo.Context, shortenedString ShortenedString) error
 	// Your GET endpoint
 	// (GET /link/{shortened_string})
-	GetLink(ctx echo.Context, shortenedString ShortenedString) error
+	GetLink(ctx echo.Context, shortenedString ShortenedString, params GetLinkParams) error
+
+	// (PATCH /link/{shortened_string})
+	UpdateLink(ctx echo.Context, shortenedString ShortenedString) error
+	// Unlock a password protected link
+	// (POST /link/{shortened_string})
+	UnlockLink(ctx echo.Context, shortenedString ShortenedString) error
+	// Preview a link
+	// (GET /link/{shortened_string}/preview)
+	PreviewLink(ctx echo.Context, shortenedString ShortenedString) error
+
+	// (GET /link/{shortened_string}/stats)
+	GetLinkStats(ctx echo.Context, shortenedString ShortenedString, params GetLinkStatsParams) error
 	// Your GET endpoint
 	// (GET /link/{shortened_string}/user)
 	GetLinkUser(ctx echo.Context, shortenedString ShortenedString) error
 
+	// (GET /links)
+	ListLinks(ctx echo.Context, params ListLinksParams) error
+
+	// (GET /links/export)
+	ExportLinks(ctx echo.Context, params ExportLinksParams) error
+
+	// (POST /links/import)
+	ImportLinks(ctx echo.Context, params ImportLinksParams) error
+
+	// (POST /links:batch)
+	CreateLinksBatch(ctx echo.Context) error
+
+	// (GET /readyz)
+	GetReadiness(ctx echo.Context) error
+
 	// (POST /user)
 	CreateUser(ctx echo.Context) error
+
+	// (GET /user/api-keys)
+	ListApiKeys(ctx echo.Context) error
+
+	// (POST /user/api-keys)
+	CreateApiKey(ctx echo.Context) error
+
+	// (DELETE /user/api-keys/{api_key_prefix})
+	RevokeApiKey(ctx echo.Context, apiKeyPrefix ApiKeyPrefix) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -32,17 +74,48 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetLiveness converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLiveness(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetLiveness(ctx)
+	return err
+}
+
 // CreateLink converts echo context to params.
 func (w *ServerInterfaceWrapper) CreateLink(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(Username_passwordScopes, []string{""})
 
+	ctx.Set(Api_keyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.CreateLink(ctx)
 	return err
 }
 
+// DeleteLink converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteLink(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shortened_string" -------------
+	var shortenedString ShortenedString
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "shortened_string", runtime.ParamLocationPath, ctx.Param("shortened_string"), &shortenedString)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shortened_string: %s", err))
+	}
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteLink(ctx, shortenedString)
+	return err
+}
+
 // GetLink converts echo context to params.
 func (w *ServerInterfaceWrapper) GetLink(ctx echo.Context) error {
 	var err error
@@ -54,8 +127,123 @@ func (w *ServerInterfaceWrapper) GetLink(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shortened_string: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "X-Link-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Link-Password")]; found {
+		var XLinkPassword LinkPassword
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Link-Password, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Link-Password", runtime.ParamLocationHeader, valueList[0], &XLinkPassword)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Link-Password: %s", err))
+		}
+
+		params.XLinkPassword = &XLinkPassword
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetLink(ctx, shortenedString, params)
+	return err
+}
+
+// UpdateLink converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateLink(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shortened_string" -------------
+	var shortenedString ShortenedString
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "shortened_string", runtime.ParamLocationPath, ctx.Param("shortened_string"), &shortenedString)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shortened_string: %s", err))
+	}
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.GetLink(ctx, shortenedString)
+	err = w.Handler.UpdateLink(ctx, shortenedString)
+	return err
+}
+
+// UnlockLink converts echo context to params.
+func (w *ServerInterfaceWrapper) UnlockLink(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shortened_string" -------------
+	var shortenedString ShortenedString
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "shortened_string", runtime.ParamLocationPath, ctx.Param("shortened_string"), &shortenedString)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shortened_string: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.UnlockLink(ctx, shortenedString)
+	return err
+}
+
+// PreviewLink converts echo context to params.
+func (w *ServerInterfaceWrapper) PreviewLink(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shortened_string" -------------
+	var shortenedString ShortenedString
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "shortened_string", runtime.ParamLocationPath, ctx.Param("shortened_string"), &shortenedString)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shortened_string: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PreviewLink(ctx, shortenedString)
+	return err
+}
+
+// GetLinkStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLinkStats(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "shortened_string" -------------
+	var shortenedString ShortenedString
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "shortened_string", runtime.ParamLocationPath, ctx.Param("shortened_string"), &shortenedString)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shortened_string: %s", err))
+	}
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkStatsParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", ctx.QueryParams(), &params.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter interval: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetLinkStats(ctx, shortenedString, params)
 	return err
 }
 
@@ -75,6 +263,101 @@ func (w *ServerInterfaceWrapper) GetLinkUser(ctx echo.Context) error {
 	return err
 }
 
+// ListLinks converts echo context to params.
+func (w *ServerInterfaceWrapper) ListLinks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLinksParams
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", ctx.QueryParams(), &params.Page)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter page: %s", err))
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", ctx.QueryParams(), &params.PerPage)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter per_page: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListLinks(ctx, params)
+	return err
+}
+
+// ExportLinks converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportLinks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportLinksParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ExportLinks(ctx, params)
+	return err
+}
+
+// ImportLinks converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportLinks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportLinksParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ImportLinks(ctx, params)
+	return err
+}
+
+// CreateLinksBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateLinksBatch(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	ctx.Set(Api_keyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateLinksBatch(ctx)
+	return err
+}
+
+// GetReadiness converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReadiness(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetReadiness(ctx)
+	return err
+}
+
 // CreateUser converts echo context to params.
 func (w *ServerInterfaceWrapper) CreateUser(ctx echo.Context) error {
 	var err error
@@ -84,6 +367,46 @@ func (w *ServerInterfaceWrapper) CreateUser(ctx echo.Context) error {
 	return err
 }
 
+// ListApiKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) ListApiKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListApiKeys(ctx)
+	return err
+}
+
+// CreateApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateApiKey(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CreateApiKey(ctx)
+	return err
+}
+
+// RevokeApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeApiKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "api_key_prefix" -------------
+	var apiKeyPrefix ApiKeyPrefix
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "api_key_prefix", runtime.ParamLocationPath, ctx.Param("api_key_prefix"), &apiKeyPrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter api_key_prefix: %s", err))
+	}
+
+	ctx.Set(Username_passwordScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RevokeApiKey(ctx, apiKeyPrefix)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -112,9 +435,23 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/healthz", wrapper.GetLiveness)
 	router.POST(baseURL+"/link", wrapper.CreateLink)
+	router.DELETE(baseURL+"/link/:shortened_string", wrapper.DeleteLink)
 	router.GET(baseURL+"/link/:shortened_string", wrapper.GetLink)
+	router.PATCH(baseURL+"/link/:shortened_string", wrapper.UpdateLink)
+	router.POST(baseURL+"/link/:shortened_string", wrapper.UnlockLink)
+	router.GET(baseURL+"/link/:shortened_string/preview", wrapper.PreviewLink)
+	router.GET(baseURL+"/link/:shortened_string/stats", wrapper.GetLinkStats)
 	router.GET(baseURL+"/link/:shortened_string/user", wrapper.GetLinkUser)
+	router.GET(baseURL+"/links", wrapper.ListLinks)
+	router.GET(baseURL+"/links/export", wrapper.ExportLinks)
+	router.POST(baseURL+"/links/import", wrapper.ImportLinks)
+	router.POST(baseURL+"/links:batch", wrapper.CreateLinksBatch)
+	router.GET(baseURL+"/readyz", wrapper.GetReadiness)
 	router.POST(baseURL+"/user", wrapper.CreateUser)
+	router.GET(baseURL+"/user/api-keys", wrapper.ListApiKeys)
+	router.POST(baseURL+"/user/api-keys", wrapper.CreateApiKey)
+	router.DELETE(baseURL+"/user/api-keys/:api_key_prefix", wrapper.RevokeApiKey)
 
 }
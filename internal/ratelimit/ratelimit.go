@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is an in memory token bucket per key. every key starts with burst
+// tokens and regains one every interval
+type Limiter struct {
+	burst    int
+	interval time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	// full buckets are dropped once in a while so keys don't pile up
+	sweptAt time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func New(burst int, interval time.Duration) *Limiter {
+	if burst < 1 {
+		panic("ratelimit: burst should be greater than 0")
+	}
+	if interval <= 0 {
+		panic("ratelimit: interval should be greater than 0")
+	}
+	return &Limiter{
+		burst:    burst,
+		interval: interval,
+		buckets:  make(map[string]*bucket),
+	}
+}
+
+// Take takes a token of key. if there's none it returns false and how long
+// until the next one
+func (l *Limiter) Take(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b := l.bucket(key, now)
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) * float64(l.interval))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Return gives back a token taken by an attempt that should not count
+func (l *Limiter) Return(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key, now)
+	b.tokens = math.Min(b.tokens+1, float64(l.burst))
+}
+
+// bucket returns the bucket of key refilled up to now
+func (l *Limiter) bucket(key string, now time.Time) *bucket {
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(l.burst), updatedAt: now}
+		l.buckets[key] = b
+		return b
+	}
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = math.Min(
+			b.tokens+float64(elapsed)/float64(l.interval),
+			float64(l.burst),
+		)
+		b.updatedAt = now
+	}
+	return b
+}
+
+// sweep drops buckets refilled by now, as a new bucket is just the same
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < l.interval {
+		return
+	}
+	l.sweptAt = now
+	for key, b := range l.buckets {
+		full := float64(now.Sub(b.updatedAt))/float64(l.interval) + b.tokens
+		if full >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
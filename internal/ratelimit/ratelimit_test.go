@@ -0,0 +1,65 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+	l := ratelimit.New(3, time.Minute)
+	now := time.Date(2023, 5, 7, 11, 0, 0, 0, time.UTC)
+
+	// burst tokens then none
+	for i := 0; i < 3; i++ {
+		ok, wait := l.Take("key", now)
+		require.True(ok)
+		require.Zero(wait)
+	}
+	ok, wait := l.Take("key", now)
+	require.False(ok)
+	require.Equal(time.Minute, wait)
+
+	// other keys have their own tokens
+	ok, _ = l.Take("another_key", now)
+	require.True(ok)
+
+	// a token is regained every interval
+	ok, wait = l.Take("key", now.Add(40*time.Second))
+	require.False(ok)
+	require.Equal(20*time.Second, wait)
+	ok, _ = l.Take("key", now.Add(time.Minute))
+	require.True(ok)
+	ok, _ = l.Take("key", now.Add(time.Minute))
+	require.False(ok)
+
+	// returned tokens can be taken again
+	l.Return("key", now.Add(time.Minute))
+	ok, _ = l.Take("key", now.Add(time.Minute))
+	require.True(ok)
+
+	// tokens never exceed burst
+	later := now.Add(time.Hour)
+	l.Return("key", later)
+	for i := 0; i < 3; i++ {
+		ok, _ = l.Take("key", later)
+		require.True(ok)
+	}
+	ok, _ = l.Take("key", later)
+	require.False(ok)
+}
+
+func TestNewPanics(t *testing.T) {
+	require := require.New(t)
+	require.PanicsWithValue(
+		"ratelimit: burst should be greater than 0",
+		func() { ratelimit.New(0, time.Minute) },
+	)
+	require.PanicsWithValue(
+		"ratelimit: interval should be greater than 0",
+		func() { ratelimit.New(1, 0) },
+	)
+}
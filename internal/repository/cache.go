@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+type CacheOptions struct {
+	// TTL bounds how long a link changed by another instance sharing the
+	// storage but not the cache may be served stale
+	TTL time.Duration
+	// NegativeTTL is how long links which don't exist are cached
+	NegativeTTL time.Duration
+}
+
+var DefaultCacheOptions = CacheOptions{
+	TTL:         time.Minute,
+	NegativeTTL: 10 * time.Second,
+}
+
+// cachedRepository reads links through cache, links are invalidated as they
+// are created, updated or deleted. only lookups are cached: clicks of links
+// with max clicks are counted by the wrapped repository as it enforces the
+// limit, the rest are counted as clicks are saved, and the click counts of
+// cached links lag until their ttl passes. methods which don't read or
+// change links are the wrapped repository's
+type cachedRepository struct {
+	port.Repository
+	cache port.LinkCache
+	opts  CacheOptions
+}
+
+func NewCachedRepository(
+	repo port.Repository,
+	cache port.LinkCache,
+	opts CacheOptions,
+) port.Repository {
+	if opts.TTL <= 0 {
+		panic("repository: cache ttl should be greater than 0")
+	}
+	if opts.NegativeTTL <= 0 {
+		panic("repository: cache negative ttl should be greater than 0")
+	}
+	return &cachedRepository{Repository: repo, cache: cache, opts: opts}
+}
+
+var _ port.Repository = (*cachedRepository)(nil)
+
+func (r *cachedRepository) GetLink(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, error) {
+	// a failing cache is read through as if it missed
+	link, found, err := r.cache.Get(ctx, shortenedString)
+	if err == nil && found {
+		if link == nil {
+			return nil, domain_errors.ErrLinkNotFound
+		}
+		return copyLink(link), nil
+	}
+
+	link, err = r.Repository.GetLink(ctx, shortenedString)
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrLinkNotFound) {
+			r.set(ctx, shortenedString, nil, r.opts.NegativeTTL)
+		}
+		return nil, err
+	}
+	// a link changed meanwhile may be cached stale until ttl passes
+	r.set(ctx, shortenedString, copyLink(link), r.opts.TTL)
+	return link, nil
+}
+
+// RegisterLinkClick leaves counted clicks to the wrapped repository, links
+// it fails to count are invalidated so they're read again as expired or gone
+func (r *cachedRepository) RegisterLinkClick(
+	ctx context.Context,
+	shortenedString string,
+	now time.Time,
+) (*domain.Link, error) {
+	link, err := r.Repository.RegisterLinkClick(ctx, shortenedString, now)
+	if err != nil {
+		r.delete(ctx, shortenedString)
+		return nil, err
+	}
+	return link, nil
+}
+
+// CreateLink drops the link's cached absence
+func (r *cachedRepository) CreateLink(ctx context.Context, link *domain.Link) error {
+	err := r.Repository.CreateLink(ctx, link)
+	if err == nil {
+		r.delete(ctx, link.ShortenedString)
+	}
+	return err
+}
+
+func (r *cachedRepository) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+) ([]int, error) {
+	used, err := r.Repository.CreateLinks(ctx, links)
+	if err == nil && len(used) == 0 {
+		for _, link := range links {
+			r.delete(ctx, link.ShortenedString)
+		}
+	}
+	return used, err
+}
+
+// UpdateLink invalidates the link whatever the result, an error doesn't mean
+// the link is not updated
+func (r *cachedRepository) UpdateLink(ctx context.Context, link *domain.Link) error {
+	err := r.Repository.UpdateLink(ctx, link)
+	r.delete(ctx, link.ShortenedString)
+	return err
+}
+
+// DeleteLink invalidates the link whatever the result, an error doesn't mean
+// the link is not deleted
+func (r *cachedRepository) DeleteLink(ctx context.Context, shortenedString string) error {
+	err := r.Repository.DeleteLink(ctx, shortenedString)
+	r.delete(ctx, shortenedString)
+	return err
+}
+
+func (r *cachedRepository) set(
+	ctx context.Context,
+	shortenedString string,
+	link *domain.Link,
+	ttl time.Duration,
+) {
+	// set failures leave the link uncached
+	_ = r.cache.Set(ctx, shortenedString, link, ttl)
+}
+
+// delete failures leave the link cached until its ttl passes
+func (r *cachedRepository) delete(ctx context.Context, shortenedString string) {
+	_ = r.cache.Delete(ctx, shortenedString)
+}
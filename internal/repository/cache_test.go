@@ -0,0 +1,250 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/aria3ppp/url-shortener-openapi/internal/linkcache"
+	"github.com/aria3ppp/url-shortener-openapi/internal/metrics"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository/repositorytest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) port.Repository {
+		return repository.NewCachedRepository(
+			repository.NewMemoryRepository(),
+			linkcache.NewLRU(100),
+			repository.DefaultCacheOptions,
+		)
+	})
+}
+
+// scrape returns the metrics m serves
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(
+		recorder,
+		httptest.NewRequest(http.MethodGet, "/metrics", nil),
+	)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestCachedRepositoryReadsThrough(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m := metrics.New()
+	mock := mockups.NewMockRepository(gomock.NewController(t))
+	repo := repository.NewCachedRepository(
+		mock,
+		metrics.NewLinkCache(linkcache.NewLRU(100), m),
+		repository.DefaultCacheOptions,
+	)
+
+	link := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+		Password:        "password_hash",
+	}
+
+	// the first lookup misses, following ones hit
+	mock.EXPECT().GetLink(gomock.Any(), "shortened_string").Return(link, nil)
+	for i := 0; i < 3; i++ {
+		got, err := repo.GetLink(ctx, "shortened_string")
+		require.NoError(err)
+		require.Equal(link, got)
+	}
+	body := scrape(t, m)
+	require.Contains(body, `url_shortener_link_cache_lookups_total{result="hit"} 2`)
+	require.Contains(body, `url_shortener_link_cache_lookups_total{result="miss"} 1`)
+
+	// cached links are copies
+	got, err := repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	got.URL = "changed_url"
+	got, err = repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal("url", got.URL)
+
+	// updates invalidate the link
+	updated := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "updated_url",
+		Username:        "username",
+	}
+	mock.EXPECT().UpdateLink(gomock.Any(), updated).Return(nil)
+	require.NoError(repo.UpdateLink(ctx, updated))
+
+	mock.EXPECT().GetLink(gomock.Any(), "shortened_string").Return(updated, nil)
+	got, err = repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal(updated, got)
+
+	// deletes invalidate the link even if they fail
+	mock.EXPECT().
+		DeleteLink(gomock.Any(), "shortened_string").
+		Return(context.DeadlineExceeded)
+	require.ErrorIs(repo.DeleteLink(ctx, "shortened_string"), context.DeadlineExceeded)
+
+	// links which don't exist are cached too
+	mock.EXPECT().
+		GetLink(gomock.Any(), "shortened_string").
+		Return(nil, domain_errors.ErrLinkNotFound)
+	for i := 0; i < 2; i++ {
+		got, err = repo.GetLink(ctx, "shortened_string")
+		require.ErrorIs(err, domain_errors.ErrLinkNotFound)
+		require.Nil(got)
+	}
+
+	// creating the link drops its cached absence
+	mock.EXPECT().CreateLink(gomock.Any(), link).Return(nil)
+	require.NoError(repo.CreateLink(ctx, link))
+
+	mock.EXPECT().GetLink(gomock.Any(), "shortened_string").Return(link, nil)
+	got, err = repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal(link, got)
+
+	// clicks are counted by the repository, cached links are kept
+	clicked := *link
+	clicked.ClickCount = 1
+	now := time.Now()
+	mock.EXPECT().
+		RegisterLinkClick(gomock.Any(), "shortened_string", now).
+		Return(&clicked, nil)
+	got, err = repo.RegisterLinkClick(ctx, "shortened_string", now)
+	require.NoError(err)
+	require.Equal(&clicked, got)
+
+	got, err = repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal(link, got)
+
+	// links which fail to be counted are invalidated
+	mock.EXPECT().
+		RegisterLinkClick(gomock.Any(), "shortened_string", now).
+		Return(nil, domain_errors.ErrLinkExpired)
+	_, err = repo.RegisterLinkClick(ctx, "shortened_string", now)
+	require.ErrorIs(err, domain_errors.ErrLinkExpired)
+
+	mock.EXPECT().GetLink(gomock.Any(), "shortened_string").Return(&clicked, nil)
+	got, err = repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal(&clicked, got)
+}
+
+func TestCachedRepositoryFollowedLinks(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	mock := mockups.NewMockRepository(gomock.NewController(t))
+	service := usecase.NewService(
+		repository.NewCachedRepository(
+			mock,
+			linkcache.NewLRU(100),
+			repository.DefaultCacheOptions,
+		),
+		nil,
+		nil,
+	)
+
+	link := &domain.Link{
+		ShortenedString: "shortened_string",
+		URL:             "url",
+		Username:        "username",
+	}
+	maxClicks := 10
+	limitedLink := &domain.Link{
+		ShortenedString: "limited",
+		URL:             "url",
+		Username:        "username",
+		MaxClicks:       &maxClicks,
+	}
+
+	// the first follow reads the link into the cache
+	mock.EXPECT().GetLink(gomock.Any(), "shortened_string").Return(link, nil)
+	got, err := service.GetLink(ctx, "shortened_string", "")
+	require.NoError(err)
+	require.Equal(link, got)
+
+	// following links without max clicks doesn't call the repository at
+	// all, their clicks are counted as they're saved
+	for i := 0; i < 3; i++ {
+		got, err = service.GetLink(ctx, "shortened_string", "")
+		require.NoError(err)
+		require.Equal(link, got)
+	}
+
+	// links with max clicks are looked up once but every click is counted
+	mock.EXPECT().GetLink(gomock.Any(), "limited").Return(limitedLink, nil)
+	for i := 1; i <= 3; i++ {
+		clicked := *limitedLink
+		clicked.ClickCount = i
+		mock.EXPECT().
+			RegisterLinkClick(gomock.Any(), "limited", gomock.Any()).
+			Return(&clicked, nil)
+
+		got, err := service.GetLink(ctx, "limited", "")
+		require.NoError(err)
+		require.Equal(&clicked, got)
+	}
+}
+
+func TestCachedRepositoryCacheErrors(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	mock := mockups.NewMockRepository(controller)
+	cache := mockups.NewMockLinkCache(controller)
+	m := metrics.New()
+	repo := repository.NewCachedRepository(
+		mock,
+		metrics.NewLinkCache(cache, m),
+		repository.DefaultCacheOptions,
+	)
+
+	link := &domain.Link{ShortenedString: "shortened_string", URL: "url"}
+	cacheErr := errors.New("cache_error")
+
+	// a failing cache is read through as if it missed
+	gomock.InOrder(
+		cache.EXPECT().
+			Get(gomock.Any(), "shortened_string").
+			Return(nil, false, cacheErr),
+		mock.EXPECT().
+			GetLink(gomock.Any(), "shortened_string").
+			Return(link, nil),
+		cache.EXPECT().
+			Set(gomock.Any(), "shortened_string", link, time.Minute).
+			Return(cacheErr),
+	)
+	got, err := repo.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal(link, got)
+
+	// writes succeed whether or not the link is invalidated
+	gomock.InOrder(
+		mock.EXPECT().UpdateLink(gomock.Any(), link).Return(nil),
+		cache.EXPECT().Delete(gomock.Any(), "shortened_string").Return(cacheErr),
+	)
+	require.NoError(repo.UpdateLink(ctx, link))
+
+	body := scrape(t, m)
+	require.Contains(body, "url_shortener_link_cache_errors_total 3")
+	require.Contains(body, `url_shortener_link_cache_lookups_total{result="miss"} 1`)
+}
@@ -0,0 +1,667 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// memoryRepository keeps everything in process memory. it's safe for
+// concurrent use and mirrors the constraints of the postgres schema
+type memoryRepository struct {
+	mu      sync.RWMutex
+	links   map[string]*domain.Link
+	users   map[string]*domain.User
+	apiKeys map[string]*domain.APIKey
+	clicks  []*domain.Click
+	idBlock int64
+}
+
+func NewMemoryRepository() port.Repository {
+	return &memoryRepository{
+		links:   make(map[string]*domain.Link),
+		users:   make(map[string]*domain.User),
+		apiKeys: make(map[string]*domain.APIKey),
+	}
+}
+
+// copyLink returns a deep copy so callers never share state with the
+// repository
+func copyLink(link *domain.Link) *domain.Link {
+	c := *link
+	if link.ExpiresAt != nil {
+		expiresAt := *link.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	if link.MaxClicks != nil {
+		maxClicks := *link.MaxClicks
+		c.MaxClicks = &maxClicks
+	}
+	if link.RedirectType != nil {
+		redirectType := *link.RedirectType
+		c.RedirectType = &redirectType
+	}
+	return &c
+}
+
+func copyAPIKey(apiKey *domain.APIKey) *domain.APIKey {
+	c := *apiKey
+	if apiKey.LastUsedAt != nil {
+		lastUsedAt := *apiKey.LastUsedAt
+		c.LastUsedAt = &lastUsedAt
+	}
+	if apiKey.ExpiresAt != nil {
+		expiresAt := *apiKey.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	return &c
+}
+
+func (r *memoryRepository) GetLink(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, exists := r.links[shortenedString]
+	if !exists {
+		return nil, domain_errors.ErrLinkNotFound
+	}
+	return copyLink(link), nil
+}
+
+func (r *memoryRepository) RegisterLinkClick(
+	ctx context.Context,
+	shortenedString string,
+	now time.Time,
+) (*domain.Link, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	link, exists := r.links[shortenedString]
+	if !exists {
+		return nil, domain_errors.ErrLinkNotFound
+	}
+	if link.Expired(now) {
+		return nil, domain_errors.ErrLinkExpired
+	}
+
+	link.ClickCount++
+	return copyLink(link), nil
+}
+
+func (r *memoryRepository) CreateLink(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.links[link.ShortenedString]; exists {
+		return domain_errors.ErrUsedShortenedString
+	}
+	if _, exists := r.users[link.Username]; !exists {
+		return domain_errors.ErrUserNotFound
+	}
+
+	c := copyLink(link)
+	c.CreatedAt = time.Now()
+	c.ClickCount = 0
+	r.links[link.ShortenedString] = c
+	return nil
+}
+
+func (r *memoryRepository) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var used []int
+	batch := make(map[string]bool, len(links))
+	for i, link := range links {
+		if _, exists := r.links[link.ShortenedString]; exists ||
+			batch[link.ShortenedString] {
+			used = append(used, i)
+		}
+		batch[link.ShortenedString] = true
+		if _, exists := r.users[link.Username]; !exists {
+			return nil, domain_errors.ErrUserNotFound
+		}
+	}
+	if len(used) > 0 {
+		return used, nil
+	}
+
+	now := time.Now()
+	for _, link := range links {
+		c := copyLink(link)
+		c.CreatedAt = now
+		c.ClickCount = 0
+		r.links[link.ShortenedString] = c
+	}
+	return used, nil
+}
+
+func (r *memoryRepository) UpdateLink(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.links[link.ShortenedString]
+	if !exists {
+		return domain_errors.ErrLinkNotFound
+	}
+	stored.URL = link.URL
+	stored.RedirectType = copyLink(link).RedirectType
+	return nil
+}
+
+func (r *memoryRepository) DeleteLink(
+	ctx context.Context,
+	shortenedString string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.links[shortenedString]; !exists {
+		return domain_errors.ErrLinkNotFound
+	}
+	delete(r.links, shortenedString)
+
+	// clicks are deleted with their link
+	clicks := r.clicks[:0]
+	for _, click := range r.clicks {
+		if click.ShortenedString != shortenedString {
+			clicks = append(clicks, click)
+		}
+	}
+	r.clicks = clicks
+
+	return nil
+}
+
+func (r *memoryRepository) ListUserLinks(
+	ctx context.Context,
+	username string,
+	offset int,
+	limit int,
+) ([]*domain.Link, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	links := r.userLinks(username)
+	if offset >= len(links) {
+		return make([]*domain.Link, 0), nil
+	}
+	links = links[offset:]
+	if limit < len(links) {
+		links = links[:limit]
+	}
+
+	result := make([]*domain.Link, 0, len(links))
+	for _, link := range links {
+		result = append(result, copyLink(link))
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) ListUserLinksAfter(
+	ctx context.Context,
+	username string,
+	after *domain.Link,
+	limit int,
+) ([]*domain.Link, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	links := r.userLinks(username)
+	if after != nil {
+		links = links[sort.Search(len(links), func(i int) bool {
+			return linkBefore(after, links[i])
+		}):]
+	}
+	if limit < len(links) {
+		links = links[:limit]
+	}
+
+	result := make([]*domain.Link, 0, len(links))
+	for _, link := range links {
+		result = append(result, copyLink(link))
+	}
+	return result, nil
+}
+
+// userLinks returns the links of username newest first like postgres, r.mu
+// should be held
+func (r *memoryRepository) userLinks(username string) []*domain.Link {
+	links := make([]*domain.Link, 0)
+	for _, link := range r.links {
+		if link.Username == username {
+			links = append(links, link)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return linkBefore(links[i], links[j])
+	})
+	return links
+}
+
+// linkBefore reports whether a is listed before b
+func linkBefore(a, b *domain.Link) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ShortenedString < b.ShortenedString
+}
+
+func (r *memoryRepository) CountUserLinks(
+	ctx context.Context,
+	username string,
+) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, link := range r.links {
+		if link.Username == username {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepository) GetUser(
+	ctx context.Context,
+	username string,
+) (*domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[username]
+	if !exists {
+		return nil, domain_errors.ErrUserNotFound
+	}
+	c := *user
+	return &c, nil
+}
+
+func (r *memoryRepository) CreateUser(
+	ctx context.Context,
+	user *domain.User,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.users[user.Username]; exists {
+		return domain_errors.ErrUsernameTaken
+	}
+	c := *user
+	r.users[user.Username] = &c
+	return nil
+}
+
+func (r *memoryRepository) UpdateUserPassword(
+	ctx context.Context,
+	username string,
+	password string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[username]
+	if !exists {
+		return domain_errors.ErrUserNotFound
+	}
+	user.Password = password
+	return nil
+}
+
+func (r *memoryRepository) GetAPIKey(
+	ctx context.Context,
+	prefix string,
+) (*domain.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	apiKey, exists := r.apiKeys[prefix]
+	if !exists {
+		return nil, domain_errors.ErrAPIKeyNotFound
+	}
+	return copyAPIKey(apiKey), nil
+}
+
+func (r *memoryRepository) ListAPIKeys(
+	ctx context.Context,
+	username string,
+) ([]*domain.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	apiKeys := make([]*domain.APIKey, 0)
+	for _, apiKey := range r.apiKeys {
+		if apiKey.Username == username {
+			apiKeys = append(apiKeys, copyAPIKey(apiKey))
+		}
+	}
+	sort.Slice(apiKeys, func(i, j int) bool {
+		if !apiKeys[i].CreatedAt.Equal(apiKeys[j].CreatedAt) {
+			return apiKeys[i].CreatedAt.Before(apiKeys[j].CreatedAt)
+		}
+		return apiKeys[i].Prefix < apiKeys[j].Prefix
+	})
+	return apiKeys, nil
+}
+
+func (r *memoryRepository) CreateAPIKey(
+	ctx context.Context,
+	apiKey *domain.APIKey,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.apiKeys[apiKey.Prefix]; exists {
+		return domain_errors.ErrUsedAPIKeyPrefix
+	}
+	if _, exists := r.users[apiKey.Username]; !exists {
+		return domain_errors.ErrUserNotFound
+	}
+	r.apiKeys[apiKey.Prefix] = copyAPIKey(apiKey)
+	return nil
+}
+
+func (r *memoryRepository) DeleteAPIKey(
+	ctx context.Context,
+	username string,
+	prefix string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	apiKey, exists := r.apiKeys[prefix]
+	if !exists || apiKey.Username != username {
+		return domain_errors.ErrAPIKeyNotFound
+	}
+	delete(r.apiKeys, prefix)
+	return nil
+}
+
+func (r *memoryRepository) UpdateAPIKeyLastUsedAt(
+	ctx context.Context,
+	prefix string,
+	lastUsedAt time.Time,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if apiKey, exists := r.apiKeys[prefix]; exists {
+		apiKey.LastUsedAt = &lastUsedAt
+	}
+	return nil
+}
+
+func (r *memoryRepository) NextIDBlock(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idBlock++
+	return r.idBlock, nil
+}
+
+func (r *memoryRepository) CreateClicks(
+	ctx context.Context,
+	clicks []*domain.Click,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, click := range clicks {
+		link, exists := r.links[click.ShortenedString]
+		if !exists {
+			continue
+		}
+		if !click.Counted {
+			link.ClickCount++
+		}
+		c := *click
+		r.clicks = append(r.clicks, &c)
+	}
+	return nil
+}
+
+// rangeClicks calls f for every click of a link in [from, to). r.mu must be
+// held
+func (r *memoryRepository) rangeClicks(
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	f func(click *domain.Click),
+) {
+	for _, click := range r.clicks {
+		if click.ShortenedString == shortenedString &&
+			!click.ClickedAt.Before(from) &&
+			click.ClickedAt.Before(to) {
+			f(click)
+		}
+	}
+}
+
+func (r *memoryRepository) CountClicks(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+) (int, int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clicks := 0
+	visitors := make(map[string]struct{})
+	r.rangeClicks(shortenedString, from, to, func(click *domain.Click) {
+		clicks++
+		visitors[click.IPHash] = struct{}{}
+	})
+	return clicks, len(visitors), nil
+}
+
+func (r *memoryRepository) ListClickBuckets(
+	ctx context.Context,
+	shortenedString string,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) ([]*domain.StatsBucket, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	buckets := make(map[int64]*domain.StatsBucket)
+	visitors := make(map[int64]map[string]struct{})
+	r.rangeClicks(shortenedString, from, to, func(click *domain.Click) {
+		start := interval.Truncate(click.ClickedAt)
+		key := start.Unix()
+		bucket, exists := buckets[key]
+		if !exists {
+			bucket = &domain.StatsBucket{Start: start}
+			buckets[key] = bucket
+			visitors[key] = make(map[string]struct{})
+		}
+		bucket.Clicks++
+		visitors[key][click.IPHash] = struct{}{}
+	})
+
+	result := make([]*domain.StatsBucket, 0, len(buckets))
+	for key, bucket := range buckets {
+		bucket.UniqueVisitors = len(visitors[key])
+		result = append(result, bucket)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result, nil
+}
+
+func (r *memoryRepository) ListTopReferrers(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.listTopClickValues(
+		func(click *domain.Click) string { return click.Referrer },
+		shortenedString,
+		from,
+		to,
+		limit,
+	), nil
+}
+
+func (r *memoryRepository) ListTopUserAgentFamilies(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.listTopClickValues(
+		func(click *domain.Click) string { return click.UserAgentFamily },
+		shortenedString,
+		from,
+		to,
+		limit,
+	), nil
+}
+
+func (r *memoryRepository) listTopClickValues(
+	value func(click *domain.Click) string,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) []*domain.StatsCount {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	r.rangeClicks(shortenedString, from, to, func(click *domain.Click) {
+		if v := value(click); v != "" {
+			counts[v]++
+		}
+	})
+
+	result := make([]*domain.StatsCount, 0, len(counts))
+	for v, count := range counts {
+		result = append(result, &domain.StatsCount{Value: v, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	if limit < len(result) {
+		result = result[:limit]
+	}
+	return result
+}
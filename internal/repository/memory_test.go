@@ -0,0 +1,15 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository/repositorytest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) port.Repository {
+		return repository.NewMemoryRepository()
+	})
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"sort"
+	"time"
 
 	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
 	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
@@ -17,15 +20,55 @@ func NewRepository(db *sql.DB) port.Repository {
 	return &postgresRepository{db: db}
 }
 
+const linkColumns = "shortened_string, url, username, created_at, expires_at, max_clicks, click_count, redirect_type, password"
+
+// uncountedClicks returns the shortened strings of the clicks which aren't
+// counted sorted, so links are updated in the same order by every batch, and
+// how many clicks of each aren't
+func uncountedClicks(clicks []*domain.Click) ([]string, map[string]int) {
+	counts := make(map[string]int)
+	var shortenedStrings []string
+	for _, click := range clicks {
+		if click.Counted {
+			continue
+		}
+		if counts[click.ShortenedString] == 0 {
+			shortenedStrings = append(shortenedStrings, click.ShortenedString)
+		}
+		counts[click.ShortenedString]++
+	}
+	sort.Strings(shortenedStrings)
+	return shortenedStrings, counts
+}
+
+func scanLink(row interface{ Scan(dest ...any) error }) (*domain.Link, error) {
+	link := new(domain.Link)
+	err := row.Scan(
+		&link.ShortenedString,
+		&link.URL,
+		&link.Username,
+		&link.CreatedAt,
+		&link.ExpiresAt,
+		&link.MaxClicks,
+		&link.ClickCount,
+		&link.RedirectType,
+		&link.Password,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
 func (r *postgresRepository) GetLink(
+	ctx context.Context,
 	shortenedString string,
 ) (*domain.Link, error) {
-	link := new(domain.Link)
-
-	err := r.db.QueryRow(
-		"SELECT shortened_string, url, username FROM links WHERE shortened_string = $1",
+	link, err := scanLink(r.db.QueryRowContext(
+		ctx,
+		"SELECT "+linkColumns+" FROM links WHERE shortened_string = $1",
 		shortenedString,
-	).Scan(&link.ShortenedString, &link.URL, &link.Username)
+	))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain_errors.ErrLinkNotFound
@@ -36,20 +79,261 @@ func (r *postgresRepository) GetLink(
 	return link, nil
 }
 
-func (r *postgresRepository) CreateLink(link *domain.Link) error {
-	_, err := r.db.Exec(
-		"INSERT INTO links (shortened_string, url, username) VALUES ($1, $2, $3)",
+func (r *postgresRepository) RegisterLinkClick(
+	ctx context.Context,
+	shortenedString string,
+	now time.Time,
+) (*domain.Link, error) {
+	// the row lock taken by update serializes concurrent clicks so
+	// max_clicks is never exceeded
+	link, err := scanLink(r.db.QueryRowContext(
+		ctx,
+		`UPDATE links SET click_count = click_count + 1
+		WHERE shortened_string = $1
+			AND (expires_at IS NULL OR expires_at > $2)
+			AND (max_clicks IS NULL OR click_count < max_clicks)
+		RETURNING `+linkColumns,
+		shortenedString,
+		now,
+	))
+	if err == nil {
+		return link, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// nothing updated: either the link don't exists or it's expired
+	_, err = r.GetLink(ctx, shortenedString)
+	if err != nil {
+		return nil, err
+	}
+	return nil, domain_errors.ErrLinkExpired
+}
+
+func (r *postgresRepository) CreateLink(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	// the conflict check is atomic with the insert so concurrent creates
+	// of the same shortened string can't both succeed
+	result, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO links (shortened_string, url, username, expires_at, max_clicks, redirect_type, password)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (shortened_string) DO NOTHING`,
 		link.ShortenedString,
 		link.URL,
 		link.Username,
+		link.ExpiresAt,
+		link.MaxClicks,
+		link.RedirectType,
+		link.Password,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUsedShortenedString
+	}
+	return nil
+}
+
+func (r *postgresRepository) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+) ([]int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO links (shortened_string, url, username, expires_at, max_clicks, redirect_type, password)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (shortened_string) DO NOTHING`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var used []int
+	for i, link := range links {
+		result, err := stmt.ExecContext(
+			ctx,
+			link.ShortenedString,
+			link.URL,
+			link.Username,
+			link.ExpiresAt,
+			link.MaxClicks,
+			link.RedirectType,
+			link.Password,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected == 0 {
+			used = append(used, i)
+		}
+	}
+	if len(used) > 0 {
+		return used, nil
+	}
+
+	return nil, tx.Commit()
+}
+
+func (r *postgresRepository) UpdateLink(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE links SET url = $2, redirect_type = $3 WHERE shortened_string = $1",
+		link.ShortenedString,
+		link.URL,
+		link.RedirectType,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteLink(
+	ctx context.Context,
+	shortenedString string,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM links WHERE shortened_string = $1",
+		shortenedString,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) ListUserLinks(
+	ctx context.Context,
+	username string,
+	offset int,
+	limit int,
+) ([]*domain.Link, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT "+linkColumns+" FROM links WHERE username = $1 ORDER BY created_at DESC, shortened_string OFFSET $2 LIMIT $3",
+		username,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*domain.Link, 0)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
 }
 
-func (r *postgresRepository) GetUser(username string) (*domain.User, error) {
+func (r *postgresRepository) ListUserLinksAfter(
+	ctx context.Context,
+	username string,
+	after *domain.Link,
+	limit int,
+) ([]*domain.Link, error) {
+	if after == nil {
+		return r.ListUserLinks(ctx, username, 0, limit)
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+linkColumns+` FROM links
+		WHERE username = $1 AND (created_at < $2 OR created_at = $2 AND shortened_string > $3)
+		ORDER BY created_at DESC, shortened_string LIMIT $4`,
+		username,
+		after.CreatedAt,
+		after.ShortenedString,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*domain.Link, 0)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *postgresRepository) CountUserLinks(
+	ctx context.Context,
+	username string,
+) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT count(*) FROM links WHERE username = $1",
+		username,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *postgresRepository) GetUser(
+	ctx context.Context,
+	username string,
+) (*domain.User, error) {
 	user := new(domain.User)
 
-	err := r.db.QueryRow(
+	err := r.db.QueryRowContext(
+		ctx,
 		"SELECT username, password FROM users WHERE username = $1",
 		username,
 	).Scan(&user.Username, &user.Password)
@@ -63,11 +347,370 @@ func (r *postgresRepository) GetUser(username string) (*domain.User, error) {
 	return user, nil
 }
 
-func (r *postgresRepository) CreateUser(user *domain.User) error {
-	_, err := r.db.Exec(
-		"INSERT INTO users (username, password) VALUES ($1, $2)",
+func (r *postgresRepository) CreateUser(
+	ctx context.Context,
+	user *domain.User,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO users (username, password) VALUES ($1, $2) ON CONFLICT (username) DO NOTHING",
 		user.Username,
 		user.Password,
 	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUsernameTaken
+	}
+	return nil
+}
+
+func (r *postgresRepository) UpdateUserPassword(
+	ctx context.Context,
+	username string,
+	password string,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE users SET password = $2 WHERE username = $1",
+		username,
+		password,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetAPIKey(
+	ctx context.Context,
+	prefix string,
+) (*domain.APIKey, error) {
+	apiKey := new(domain.APIKey)
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT prefix, hash, name, username, created_at, last_used_at, expires_at FROM api_keys WHERE prefix = $1",
+		prefix,
+	).Scan(
+		&apiKey.Prefix,
+		&apiKey.Hash,
+		&apiKey.Name,
+		&apiKey.Username,
+		&apiKey.CreatedAt,
+		&apiKey.LastUsedAt,
+		&apiKey.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain_errors.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (r *postgresRepository) ListAPIKeys(
+	ctx context.Context,
+	username string,
+) ([]*domain.APIKey, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT prefix, hash, name, username, created_at, last_used_at, expires_at FROM api_keys WHERE username = $1 ORDER BY created_at, prefix",
+		username,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := make([]*domain.APIKey, 0)
+	for rows.Next() {
+		apiKey := new(domain.APIKey)
+		err := rows.Scan(
+			&apiKey.Prefix,
+			&apiKey.Hash,
+			&apiKey.Name,
+			&apiKey.Username,
+			&apiKey.CreatedAt,
+			&apiKey.LastUsedAt,
+			&apiKey.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (r *postgresRepository) CreateAPIKey(
+	ctx context.Context,
+	apiKey *domain.APIKey,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO api_keys (prefix, hash, name, username, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (prefix) DO NOTHING",
+		apiKey.Prefix,
+		apiKey.Hash,
+		apiKey.Name,
+		apiKey.Username,
+		apiKey.CreatedAt,
+		apiKey.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUsedAPIKeyPrefix
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteAPIKey(
+	ctx context.Context,
+	username string,
+	prefix string,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM api_keys WHERE username = $1 AND prefix = $2",
+		username,
+		prefix,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) UpdateAPIKeyLastUsedAt(
+	ctx context.Context,
+	prefix string,
+	lastUsedAt time.Time,
+) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"UPDATE api_keys SET last_used_at = $2 WHERE prefix = $1",
+		prefix,
+		lastUsedAt,
+	)
 	return err
 }
+
+func (r *postgresRepository) NextIDBlock(ctx context.Context) (int64, error) {
+	var block int64
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT nextval('short_code_block_seq')",
+	).Scan(&block)
+	return block, err
+}
+
+func (r *postgresRepository) CreateClicks(
+	ctx context.Context,
+	clicks []*domain.Click,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO clicks (shortened_string, referrer, user_agent, user_agent_family, ip_hash, clicked_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE EXISTS (SELECT 1 FROM links WHERE shortened_string = $1)`,
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, click := range clicks {
+		_, err = stmt.ExecContext(
+			ctx,
+			click.ShortenedString,
+			click.Referrer,
+			click.UserAgent,
+			click.UserAgentFamily,
+			click.IPHash,
+			click.ClickedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	shortenedStrings, counts := uncountedClicks(clicks)
+	for _, shortenedString := range shortenedStrings {
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE links SET click_count = click_count + $2 WHERE shortened_string = $1",
+			shortenedString,
+			counts[shortenedString],
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresRepository) CountClicks(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+) (int, int, error) {
+	var clicks, uniqueVisitors int
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT count(*), count(DISTINCT ip_hash) FROM clicks
+		WHERE shortened_string = $1 AND clicked_at >= $2 AND clicked_at < $3`,
+		shortenedString,
+		from,
+		to,
+	).Scan(&clicks, &uniqueVisitors)
+	if err != nil {
+		return 0, 0, err
+	}
+	return clicks, uniqueVisitors, nil
+}
+
+func (r *postgresRepository) ListClickBuckets(
+	ctx context.Context,
+	shortenedString string,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) ([]*domain.StatsBucket, error) {
+	// buckets are truncated in utc to match domain.StatsInterval.Truncate
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT date_trunc($2::text, clicked_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS start,
+			count(*),
+			count(DISTINCT ip_hash)
+		FROM clicks
+		WHERE shortened_string = $1 AND clicked_at >= $3 AND clicked_at < $4
+		GROUP BY start
+		ORDER BY start`,
+		shortenedString,
+		string(interval),
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]*domain.StatsBucket, 0)
+	for rows.Next() {
+		bucket := new(domain.StatsBucket)
+		err = rows.Scan(&bucket.Start, &bucket.Clicks, &bucket.UniqueVisitors)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func (r *postgresRepository) ListTopReferrers(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	return r.listTopClickValues(ctx, "referrer", shortenedString, from, to, limit)
+}
+
+func (r *postgresRepository) ListTopUserAgentFamilies(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	return r.listTopClickValues(ctx, "user_agent_family", shortenedString, from, to, limit)
+}
+
+// listTopClickValues counts non empty values of a clicks column, column is
+// never user input
+func (r *postgresRepository) listTopClickValues(
+	ctx context.Context,
+	column string,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+column+`, count(*) AS count FROM clicks
+		WHERE shortened_string = $1 AND clicked_at >= $2 AND clicked_at < $3
+			AND `+column+` <> ''
+		GROUP BY `+column+`
+		ORDER BY count DESC, `+column+`
+		LIMIT $4`,
+		shortenedString,
+		from,
+		to,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]*domain.StatsCount, 0)
+	for rows.Next() {
+		count := new(domain.StatsCount)
+		err = rows.Scan(&count.Value, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,1068 @@
+// Package repositorytest is a contract test suite every port.Repository
+// implementation should pass
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/stretchr/testify/require"
+)
+
+var ctx = context.Background()
+
+// Run runs the contract tests each against an empty repository returned by
+// newRepository
+func Run(t *testing.T, newRepository func(t *testing.T) port.Repository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.Repository)
+	}{
+		{"GetLink", testGetLink},
+		{"CreateLink", testCreateLink},
+		{"UpdateLink", testUpdateLink},
+		{"DeleteLink", testDeleteLink},
+		{"RegisterLinkClick", testRegisterLinkClick},
+		{"ConcurrentLinkClicks", testConcurrentLinkClicks},
+		{"ConcurrentCreateLink", testConcurrentCreateLink},
+		{"CreateLinks", testCreateLinks},
+		{"ListUserLinks", testListUserLinks},
+		{"GetUser", testGetUser},
+		{"CreateUser", testCreateUser},
+		{"ConcurrentCreateUser", testConcurrentCreateUser},
+		{"UpdateUserPassword", testUpdateUserPassword},
+		{"APIKeys", testAPIKeys},
+		{"NextIDBlock", testNextIDBlock},
+		{"CreateClicks", testCreateClicks},
+		{"ClickStats", testClickStats},
+		{"CancelledContext", testCancelledContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testGetLink(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// first there's no link
+	link, err := r.GetLink(ctx, linkShortenedString)
+	require.Equal(err, domain_errors.ErrLinkNotFound)
+	require.Nil(link)
+
+	// create a new link
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	// get link
+	link, err = r.GetLink(ctx, linkShortenedString)
+	require.NoError(err)
+	require.False(link.CreatedAt.IsZero())
+	link.CreatedAt = time.Time{} // set by the database
+	require.Equal(
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		},
+		link,
+	)
+}
+
+func testCreateLink(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// create link
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	// assert link is created
+	link, err := r.GetLink(ctx, linkShortenedString)
+	require.NoError(err)
+	require.False(link.CreatedAt.IsZero())
+	link.CreatedAt = time.Time{} // set by the database
+	require.Equal(
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		},
+		link,
+	)
+
+	// shortened string is unique
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "other_url",
+			Username:        user.Username,
+		},
+	)
+	require.Equal(domain_errors.ErrUsedShortenedString, err)
+
+	// link is owned by an existing user
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "SnakeEater",
+			URL:             "url",
+			Username:        "not_existing_username",
+		},
+	)
+	require.Error(err)
+
+	// redirect type is saved and returned by clicks
+	redirectType := domain.RedirectTemporaryRedirect
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "TheBoss",
+			URL:             "url",
+			Username:        user.Username,
+			RedirectType:    &redirectType,
+		},
+	)
+	require.NoError(err)
+
+	link, err = r.GetLink(ctx, "TheBoss")
+	require.NoError(err)
+	require.Equal(&redirectType, link.RedirectType)
+
+	link, err = r.RegisterLinkClick(ctx, "TheBoss", time.Now())
+	require.NoError(err)
+	require.Equal(&redirectType, link.RedirectType)
+
+	// password hash is saved and returned by clicks
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "BigBoss",
+			URL:             "url",
+			Username:        user.Username,
+			Password:        "password_hash",
+		},
+	)
+	require.NoError(err)
+
+	link, err = r.GetLink(ctx, "BigBoss")
+	require.NoError(err)
+	require.Equal("password_hash", link.Password)
+
+	link, err = r.RegisterLinkClick(ctx, "BigBoss", time.Now())
+	require.NoError(err)
+	require.Equal("password_hash", link.Password)
+
+	// links are public by default
+	link, err = r.GetLink(ctx, "TheBoss")
+	require.NoError(err)
+	require.Empty(link.Password)
+}
+
+func testUpdateLink(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// link not found
+	err = r.UpdateLink(ctx, &domain.Link{
+		ShortenedString: linkShortenedString,
+		URL:             "updated_url",
+	})
+	require.Equal(domain_errors.ErrLinkNotFound, err)
+
+	// create a new link
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	// update link
+	redirectType := domain.RedirectFound
+	err = r.UpdateLink(ctx, &domain.Link{
+		ShortenedString: linkShortenedString,
+		URL:             "updated_url",
+		RedirectType:    &redirectType,
+	})
+	require.NoError(err)
+
+	// assert link is updated
+	link, err := r.GetLink(ctx, linkShortenedString)
+	require.NoError(err)
+	require.Equal("updated_url", link.URL)
+	require.Equal(user.Username, link.Username)
+	require.Equal(&redirectType, link.RedirectType)
+
+	// redirect type is reset to the server default
+	err = r.UpdateLink(ctx, &domain.Link{
+		ShortenedString: linkShortenedString,
+		URL:             "updated_url",
+	})
+	require.NoError(err)
+
+	link, err = r.GetLink(ctx, linkShortenedString)
+	require.NoError(err)
+	require.Nil(link.RedirectType)
+}
+
+func testDeleteLink(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// link not found
+	err = r.DeleteLink(ctx, linkShortenedString)
+	require.Equal(domain_errors.ErrLinkNotFound, err)
+
+	// create a new link
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: linkShortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	// delete link
+	err = r.DeleteLink(ctx, linkShortenedString)
+	require.NoError(err)
+
+	// assert link is deleted
+	link, err := r.GetLink(ctx, linkShortenedString)
+	require.Equal(domain_errors.ErrLinkNotFound, err)
+	require.Nil(link)
+}
+
+func testRegisterLinkClick(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	now := time.Now()
+
+	// link not found
+	link, err := r.RegisterLinkClick(ctx, "LaLiLuLeLo", now)
+	require.Equal(domain_errors.ErrLinkNotFound, err)
+	require.Nil(link)
+
+	// click count is incremented until max clicks is reached
+	maxClicks := 2
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "max_clicks",
+			URL:             "url",
+			Username:        user.Username,
+			MaxClicks:       &maxClicks,
+		},
+	)
+	require.NoError(err)
+
+	for i := 1; i <= maxClicks; i++ {
+		link, err = r.RegisterLinkClick(ctx, "max_clicks", now)
+		require.NoError(err)
+		require.Equal(i, link.ClickCount)
+	}
+
+	link, err = r.RegisterLinkClick(ctx, "max_clicks", now)
+	require.Equal(domain_errors.ErrLinkExpired, err)
+	require.Nil(link)
+
+	// link expires at expires_at
+	expiresAt := now.Add(time.Hour)
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "expires_at",
+			URL:             "url",
+			Username:        user.Username,
+			ExpiresAt:       &expiresAt,
+		},
+	)
+	require.NoError(err)
+
+	link, err = r.RegisterLinkClick(ctx, "expires_at", now)
+	require.NoError(err)
+	require.Equal(1, link.ClickCount)
+
+	link, err = r.RegisterLinkClick(ctx, "expires_at", expiresAt)
+	require.Equal(domain_errors.ErrLinkExpired, err)
+	require.Nil(link)
+}
+
+func testConcurrentLinkClicks(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	maxClicks := 10
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "LaLiLuLeLo",
+			URL:             "url",
+			Username:        user.Username,
+			MaxClicks:       &maxClicks,
+		},
+	)
+	require.NoError(err)
+
+	// concurrent clicks never exceed max clicks
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+		expired   atomic.Int32
+	)
+	now := time.Now()
+	for i := 0; i < 3*maxClicks; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.RegisterLinkClick(ctx, "LaLiLuLeLo", now)
+			switch err {
+			case nil:
+				succeeded.Add(1)
+			case domain_errors.ErrLinkExpired:
+				expired.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(int32(maxClicks), succeeded.Load())
+	require.Equal(int32(2*maxClicks), expired.Load())
+
+	link, err := r.GetLink(ctx, "LaLiLuLeLo")
+	require.NoError(err)
+	require.Equal(maxClicks, link.ClickCount)
+}
+
+func testConcurrentCreateLink(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+
+	// only one of concurrent creates of the same link succeeds
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+		used      atomic.Int32
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := r.CreateLink(
+				ctx,
+				&domain.Link{
+					ShortenedString: "LaLiLuLeLo",
+					URL:             fmt.Sprintf("url_%d", i),
+					Username:        user.Username,
+				},
+			)
+			switch err {
+			case nil:
+				succeeded.Add(1)
+			case domain_errors.ErrUsedShortenedString:
+				used.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(int32(1), succeeded.Load())
+	require.Equal(int32(19), used.Load())
+}
+
+func testCreateLinks(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "LaLiLuLeLo",
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	newLinks := func(shortenedStrings ...string) []*domain.Link {
+		links := make([]*domain.Link, 0, len(shortenedStrings))
+		for _, shortenedString := range shortenedStrings {
+			links = append(links, &domain.Link{
+				ShortenedString: shortenedString,
+				URL:             "url_" + shortenedString,
+				Username:        user.Username,
+			})
+		}
+		return links
+	}
+
+	// used shortened strings and duplicates in the batch create nothing
+	used, err := r.CreateLinks(
+		ctx,
+		newLinks("Solid", "LaLiLuLeLo", "Liquid", "Solid"),
+	)
+	require.NoError(err)
+	require.Equal([]int{1, 3}, used)
+	for _, shortenedString := range []string{"Solid", "Liquid"} {
+		_, err = r.GetLink(ctx, shortenedString)
+		require.ErrorIs(err, domain_errors.ErrLinkNotFound)
+	}
+
+	// otherwise every link is created
+	used, err = r.CreateLinks(ctx, newLinks("Solid", "Liquid", "Solidus"))
+	require.NoError(err)
+	require.Empty(used)
+	for _, shortenedString := range []string{"Solid", "Liquid", "Solidus"} {
+		link, err := r.GetLink(ctx, shortenedString)
+		require.NoError(err)
+		require.Equal("url_"+shortenedString, link.URL)
+		require.Equal(user.Username, link.Username)
+		require.False(link.CreatedAt.IsZero())
+	}
+
+	count, err := r.CountUserLinks(ctx, user.Username)
+	require.NoError(err)
+	require.Equal(4, count)
+}
+
+func testListUserLinks(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper users
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+	otherUser := &domain.User{Username: "other_username"}
+	err = r.CreateUser(ctx, otherUser)
+	require.NoError(err)
+
+	// first there's no link
+	links, err := r.ListUserLinks(ctx, user.Username, 0, 10)
+	require.NoError(err)
+	require.Empty(links)
+	count, err := r.CountUserLinks(ctx, user.Username)
+	require.NoError(err)
+	require.Equal(0, count)
+
+	// create links
+	for _, shortenedString := range []string{"link01", "link02", "link03"} {
+		err = r.CreateLink(ctx, &domain.Link{
+			ShortenedString: shortenedString,
+			URL:             "url",
+			Username:        user.Username,
+		})
+		require.NoError(err)
+	}
+	err = r.CreateLink(ctx, &domain.Link{
+		ShortenedString: "link04",
+		URL:             "url",
+		Username:        otherUser.Username,
+	})
+	require.NoError(err)
+
+	count, err = r.CountUserLinks(ctx, user.Username)
+	require.NoError(err)
+	require.Equal(3, count)
+
+	// paginate user links
+	links, err = r.ListUserLinks(ctx, user.Username, 0, 2)
+	require.NoError(err)
+	require.Len(links, 2)
+	otherLinks, err := r.ListUserLinks(ctx, user.Username, 2, 2)
+	require.NoError(err)
+	require.Len(otherLinks, 1)
+
+	shortenedStrings := map[string]bool{}
+	for _, link := range append(links, otherLinks...) {
+		require.Equal(user.Username, link.Username)
+		shortenedStrings[link.ShortenedString] = true
+	}
+	require.Equal(
+		map[string]bool{"link01": true, "link02": true, "link03": true},
+		shortenedStrings,
+	)
+
+	// pages after the last link of the previous page are the offset pages
+	keysetLinks, err := r.ListUserLinksAfter(ctx, user.Username, nil, 2)
+	require.NoError(err)
+	require.Equal(links, keysetLinks)
+	keysetLinks, err = r.ListUserLinksAfter(ctx, user.Username, keysetLinks[1], 2)
+	require.NoError(err)
+	require.Equal(otherLinks, keysetLinks)
+	keysetLinks, err = r.ListUserLinksAfter(ctx, user.Username, keysetLinks[0], 2)
+	require.NoError(err)
+	require.Empty(keysetLinks)
+}
+
+func testGetUser(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	username := "snakePlissken"
+
+	// first there's no user
+	user, err := r.GetUser(ctx, username)
+	require.Equal(err, domain_errors.ErrUserNotFound)
+	require.Nil(user)
+
+	// create a new user
+	err = r.CreateUser(ctx, &domain.User{
+		Username: username,
+		Password: "password",
+	})
+	require.NoError(err)
+
+	// get user
+	user, err = r.GetUser(ctx, username)
+	require.NoError(err)
+	require.Equal(
+		&domain.User{
+			Username: username,
+			Password: "password",
+		},
+		user,
+	)
+}
+
+func testCreateUser(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	username := "snakePlissken"
+
+	// create user
+	err := r.CreateUser(
+		ctx,
+		&domain.User{
+			Username: username,
+			Password: "password",
+		},
+	)
+	require.NoError(err)
+
+	// assert user is created
+	user, err := r.GetUser(ctx, username)
+	require.NoError(err)
+	require.Equal(
+		&domain.User{
+			Username: username,
+			Password: "password",
+		},
+		user,
+	)
+
+	// username is unique
+	err = r.CreateUser(
+		ctx,
+		&domain.User{
+			Username: username,
+			Password: "other_password",
+		},
+	)
+	require.Equal(domain_errors.ErrUsernameTaken, err)
+}
+
+func testConcurrentCreateUser(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// only one of concurrent creates of the same user succeeds
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+		taken     atomic.Int32
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := r.CreateUser(
+				ctx,
+				&domain.User{
+					Username: "snakePlissken",
+					Password: fmt.Sprintf("password_%d", i),
+				},
+			)
+			switch err {
+			case nil:
+				succeeded.Add(1)
+			case domain_errors.ErrUsernameTaken:
+				taken.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(int32(1), succeeded.Load())
+	require.Equal(int32(19), taken.Load())
+}
+
+func testUpdateUserPassword(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	username := "snakePlissken"
+
+	// user not found
+	err := r.UpdateUserPassword(ctx, username, "password_hash")
+	require.Equal(domain_errors.ErrUserNotFound, err)
+
+	// create user with a legacy plain text password
+	err = r.CreateUser(
+		ctx,
+		&domain.User{
+			Username: username,
+			Password: "password",
+		},
+	)
+	require.NoError(err)
+
+	// update password to an encoded hash longer than legacy column width
+	passwordHash := "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+	err = r.UpdateUserPassword(ctx, username, passwordHash)
+	require.NoError(err)
+
+	// assert password is updated
+	user, err := r.GetUser(ctx, username)
+	require.NoError(err)
+	require.Equal(
+		&domain.User{
+			Username: username,
+			Password: passwordHash,
+		},
+		user,
+	)
+}
+
+func testAPIKeys(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper users
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+	otherUser := &domain.User{Username: "other_username"}
+	err = r.CreateUser(ctx, otherUser)
+	require.NoError(err)
+
+	// first there's no api key
+	apiKey, err := r.GetAPIKey(ctx, "prefix01")
+	require.Equal(domain_errors.ErrAPIKeyNotFound, err)
+	require.Nil(apiKey)
+	apiKeys, err := r.ListAPIKeys(ctx, user.Username)
+	require.NoError(err)
+	require.Empty(apiKeys)
+
+	// create api keys
+	createdAt := time.Date(2023, 3, 12, 16, 40, 33, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	apiKey1 := &domain.APIKey{
+		Prefix:    "prefix01",
+		Hash:      "hash01",
+		Name:      "ci",
+		Username:  user.Username,
+		CreatedAt: createdAt,
+		ExpiresAt: &expiresAt,
+	}
+	apiKey2 := &domain.APIKey{
+		Prefix:    "prefix02",
+		Hash:      "hash02",
+		Name:      "cli",
+		Username:  user.Username,
+		CreatedAt: createdAt.Add(time.Second),
+	}
+	apiKey3 := &domain.APIKey{
+		Prefix:    "prefix03",
+		Hash:      "hash03",
+		Name:      "ci",
+		Username:  otherUser.Username,
+		CreatedAt: createdAt,
+	}
+	for _, k := range []*domain.APIKey{apiKey1, apiKey2, apiKey3} {
+		err = r.CreateAPIKey(ctx, k)
+		require.NoError(err)
+	}
+
+	// prefixes are unique
+	err = r.CreateAPIKey(ctx, &domain.APIKey{
+		Prefix:    apiKey1.Prefix,
+		Hash:      "hash04",
+		Name:      "other",
+		Username:  otherUser.Username,
+		CreatedAt: createdAt,
+	})
+	require.Equal(domain_errors.ErrUsedAPIKeyPrefix, err)
+
+	// get api key
+	apiKey, err = r.GetAPIKey(ctx, apiKey1.Prefix)
+	require.NoError(err)
+	require.Equal(apiKey1.Hash, apiKey.Hash)
+	require.Equal(apiKey1.Name, apiKey.Name)
+	require.Equal(apiKey1.Username, apiKey.Username)
+	require.True(apiKey1.CreatedAt.Equal(apiKey.CreatedAt))
+	require.True(apiKey1.ExpiresAt.Equal(*apiKey.ExpiresAt))
+	require.Nil(apiKey.LastUsedAt)
+
+	// list only user's api keys
+	apiKeys, err = r.ListAPIKeys(ctx, user.Username)
+	require.NoError(err)
+	require.Len(apiKeys, 2)
+	require.Equal(apiKey1.Prefix, apiKeys[0].Prefix)
+	require.Equal(apiKey2.Prefix, apiKeys[1].Prefix)
+
+	// update last used time
+	lastUsedAt := createdAt.Add(time.Hour)
+	err = r.UpdateAPIKeyLastUsedAt(ctx, apiKey1.Prefix, lastUsedAt)
+	require.NoError(err)
+	apiKey, err = r.GetAPIKey(ctx, apiKey1.Prefix)
+	require.NoError(err)
+	require.True(lastUsedAt.Equal(*apiKey.LastUsedAt))
+
+	// only owner can delete the api key
+	err = r.DeleteAPIKey(ctx, otherUser.Username, apiKey1.Prefix)
+	require.Equal(domain_errors.ErrAPIKeyNotFound, err)
+	err = r.DeleteAPIKey(ctx, user.Username, apiKey1.Prefix)
+	require.NoError(err)
+	err = r.DeleteAPIKey(ctx, user.Username, apiKey1.Prefix)
+	require.Equal(domain_errors.ErrAPIKeyNotFound, err)
+
+	apiKey, err = r.GetAPIKey(ctx, apiKey1.Prefix)
+	require.Equal(domain_errors.ErrAPIKeyNotFound, err)
+	require.Nil(apiKey)
+}
+
+func testNextIDBlock(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	first, err := r.NextIDBlock(ctx)
+	require.NoError(err)
+	second, err := r.NextIDBlock(ctx)
+	require.NoError(err)
+	require.Greater(second, first)
+
+	// concurrent callers never get the same block
+	const callers = 20
+	blocks := make(chan int64, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			block, err := r.NextIDBlock(ctx)
+			require.NoError(err)
+			blocks <- block
+		}()
+	}
+	wg.Wait()
+	close(blocks)
+
+	seen := map[int64]bool{first: true, second: true}
+	for block := range blocks {
+		require.False(seen[block])
+		seen[block] = true
+	}
+}
+
+func testCreateClicks(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user and link
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "LaLiLuLeLo",
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	clickedAt := time.Now()
+	err = r.CreateClicks(ctx, []*domain.Click{
+		{
+			ShortenedString: "LaLiLuLeLo",
+			Referrer:        "referrer",
+			UserAgent:       "user_agent",
+			IPHash:          "ip_hash",
+			ClickedAt:       clickedAt,
+		},
+		// clicks counted as followed are not counted again
+		{
+			ShortenedString: "LaLiLuLeLo",
+			IPHash:          "ip_hash",
+			ClickedAt:       clickedAt,
+			Counted:         true,
+		},
+		// clicks of not existing links are dropped
+		{
+			ShortenedString: "deleted",
+			IPHash:          "ip_hash",
+			ClickedAt:       clickedAt,
+		},
+	})
+	require.NoError(err)
+
+	from, to := clickedAt.Add(-time.Minute), clickedAt.Add(time.Minute)
+	clicks, _, err := r.CountClicks(ctx, "LaLiLuLeLo", from, to)
+	require.NoError(err)
+	require.Equal(2, clicks)
+	clicks, _, err = r.CountClicks(ctx, "deleted", from, to)
+	require.NoError(err)
+	require.Equal(0, clicks)
+
+	link, err := r.GetLink(ctx, "LaLiLuLeLo")
+	require.NoError(err)
+	require.Equal(1, link.ClickCount)
+
+	// clicks are deleted with their link
+	err = r.DeleteLink(ctx, "LaLiLuLeLo")
+	require.NoError(err)
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "LaLiLuLeLo",
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+	clicks, _, err = r.CountClicks(ctx, "LaLiLuLeLo", from, to)
+	require.NoError(err)
+	require.Equal(0, clicks)
+}
+
+func testClickStats(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	// create helper user and link
+	user := &domain.User{Username: "username"}
+	err := r.CreateUser(ctx, user)
+	require.NoError(err)
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "LaLiLuLeLo",
+			URL:             "url",
+			Username:        user.Username,
+		},
+	)
+	require.NoError(err)
+
+	day := time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC)
+	err = r.CreateClicks(ctx, []*domain.Click{
+		{
+			ShortenedString: "LaLiLuLeLo",
+			Referrer:        "https://a.example",
+			UserAgentFamily: "Firefox",
+			IPHash:          "ip_hash_1",
+			ClickedAt:       day.Add(10*time.Hour + 5*time.Minute),
+		},
+		{
+			ShortenedString: "LaLiLuLeLo",
+			Referrer:        "https://a.example",
+			UserAgentFamily: "Firefox",
+			IPHash:          "ip_hash_1",
+			ClickedAt:       day.Add(10*time.Hour + 50*time.Minute),
+		},
+		{
+			ShortenedString: "LaLiLuLeLo",
+			Referrer:        "https://b.example",
+			UserAgentFamily: "Chrome",
+			IPHash:          "ip_hash_2",
+			ClickedAt:       day.Add(12 * time.Hour),
+		},
+		{
+			ShortenedString: "LaLiLuLeLo",
+			UserAgentFamily: "Chrome",
+			IPHash:          "ip_hash_3",
+			ClickedAt:       day.Add(12*time.Hour + time.Minute),
+		},
+		// out of range
+		{
+			ShortenedString: "LaLiLuLeLo",
+			Referrer:        "https://c.example",
+			UserAgentFamily: "Safari",
+			IPHash:          "ip_hash_4",
+			ClickedAt:       day.Add(24 * time.Hour),
+		},
+	})
+	require.NoError(err)
+
+	from, to := day, day.Add(24*time.Hour)
+
+	clicks, uniqueVisitors, err := r.CountClicks(ctx, "LaLiLuLeLo", from, to)
+	require.NoError(err)
+	require.Equal(4, clicks)
+	require.Equal(3, uniqueVisitors)
+
+	buckets, err := r.ListClickBuckets(
+		ctx,
+		"LaLiLuLeLo",
+		domain.StatsIntervalHour,
+		from,
+		to,
+	)
+	require.NoError(err)
+	require.Len(buckets, 2)
+	require.True(day.Add(10 * time.Hour).Equal(buckets[0].Start))
+	require.Equal(2, buckets[0].Clicks)
+	require.Equal(1, buckets[0].UniqueVisitors)
+	require.True(day.Add(12 * time.Hour).Equal(buckets[1].Start))
+	require.Equal(2, buckets[1].Clicks)
+	require.Equal(2, buckets[1].UniqueVisitors)
+
+	// 2023-04-09 is a sunday so its week starts on 2023-04-03
+	buckets, err = r.ListClickBuckets(
+		ctx,
+		"LaLiLuLeLo",
+		domain.StatsIntervalWeek,
+		from,
+		to,
+	)
+	require.NoError(err)
+	require.Len(buckets, 1)
+	require.True(time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC).Equal(buckets[0].Start))
+	require.Equal(4, buckets[0].Clicks)
+
+	referrers, err := r.ListTopReferrers(ctx, "LaLiLuLeLo", from, to, 10)
+	require.NoError(err)
+	require.Equal(
+		[]*domain.StatsCount{
+			{Value: "https://a.example", Count: 2},
+			{Value: "https://b.example", Count: 1},
+		},
+		referrers,
+	)
+
+	userAgents, err := r.ListTopUserAgentFamilies(ctx, "LaLiLuLeLo", from, to, 1)
+	require.NoError(err)
+	require.Equal(
+		[]*domain.StatsCount{{Value: "Chrome", Count: 2}},
+		userAgents,
+	)
+}
+
+func testCancelledContext(t *testing.T, r port.Repository) {
+	require := require.New(t)
+
+	err := r.CreateUser(ctx, &domain.User{Username: "username"})
+	require.NoError(err)
+	err = r.CreateLink(
+		ctx,
+		&domain.Link{
+			ShortenedString: "shortened_string",
+			URL:             "url",
+			Username:        "username",
+		},
+	)
+	require.NoError(err)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// reads and writes are both aborted
+	link, err := r.GetLink(cancelledCtx, "shortened_string")
+	require.True(errors.Is(err, context.Canceled), err)
+	require.Nil(link)
+
+	link, err = r.RegisterLinkClick(cancelledCtx, "shortened_string", time.Now())
+	require.True(errors.Is(err, context.Canceled), err)
+	require.Nil(link)
+
+	err = r.CreateUser(cancelledCtx, &domain.User{Username: "another_username"})
+	require.True(errors.Is(err, context.Canceled), err)
+
+	err = r.CreateClicks(
+		cancelledCtx,
+		[]*domain.Click{{ShortenedString: "shortened_string", ClickedAt: time.Now()}},
+	)
+	require.True(errors.Is(err, context.Canceled), err)
+
+	// nothing was written
+	link, err = r.GetLink(ctx, "shortened_string")
+	require.NoError(err)
+	require.Equal(0, link.ClickCount)
+
+	_, err = r.GetUser(ctx, "another_username")
+	require.Equal(domain_errors.ErrUserNotFound, err)
+
+	clicks, _, err := r.CountClicks(
+		ctx,
+		"shortened_string",
+		time.Now().Add(-time.Hour),
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(err)
+	require.Equal(0, clicks)
+}
@@ -0,0 +1,700 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// sqliteRepository expects db to be opened with foreign keys enabled. times
+// are always bound in utc so the text sqlite saves them as compares in order
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) port.Repository {
+	return &sqliteRepository{db: db}
+}
+
+// utcTime converts an optional time to utc
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+func (r *sqliteRepository) GetLink(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, error) {
+	link, err := scanLink(r.db.QueryRowContext(
+		ctx,
+		"SELECT "+linkColumns+" FROM links WHERE shortened_string = ?",
+		shortenedString,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain_errors.ErrLinkNotFound
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (r *sqliteRepository) RegisterLinkClick(
+	ctx context.Context,
+	shortenedString string,
+	now time.Time,
+) (*domain.Link, error) {
+	// sqlite serializes writers so max_clicks is never exceeded
+	link, err := scanLink(r.db.QueryRowContext(
+		ctx,
+		`UPDATE links SET click_count = click_count + 1
+		WHERE shortened_string = ?1
+			AND (expires_at IS NULL OR expires_at > ?2)
+			AND (max_clicks IS NULL OR click_count < max_clicks)
+		RETURNING `+linkColumns,
+		shortenedString,
+		now.UTC(),
+	))
+	if err == nil {
+		return link, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// nothing updated: either the link don't exists or it's expired
+	_, err = r.GetLink(ctx, shortenedString)
+	if err != nil {
+		return nil, err
+	}
+	return nil, domain_errors.ErrLinkExpired
+}
+
+func (r *sqliteRepository) CreateLink(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO links (shortened_string, url, username, created_at, expires_at, max_clicks, redirect_type, password)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (shortened_string) DO NOTHING`,
+		link.ShortenedString,
+		link.URL,
+		link.Username,
+		time.Now().UTC(),
+		utcTime(link.ExpiresAt),
+		link.MaxClicks,
+		link.RedirectType,
+		link.Password,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUsedShortenedString
+	}
+	return nil
+}
+
+func (r *sqliteRepository) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+) ([]int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO links (shortened_string, url, username, created_at, expires_at, max_clicks, redirect_type, password)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (shortened_string) DO NOTHING`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	now := time.Now().UTC()
+	var used []int
+	for i, link := range links {
+		result, err := stmt.ExecContext(
+			ctx,
+			link.ShortenedString,
+			link.URL,
+			link.Username,
+			now,
+			utcTime(link.ExpiresAt),
+			link.MaxClicks,
+			link.RedirectType,
+			link.Password,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected == 0 {
+			used = append(used, i)
+		}
+	}
+	if len(used) > 0 {
+		return used, nil
+	}
+
+	return nil, tx.Commit()
+}
+
+func (r *sqliteRepository) UpdateLink(
+	ctx context.Context,
+	link *domain.Link,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE links SET url = ?2, redirect_type = ?3 WHERE shortened_string = ?1",
+		link.ShortenedString,
+		link.URL,
+		link.RedirectType,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *sqliteRepository) DeleteLink(
+	ctx context.Context,
+	shortenedString string,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM links WHERE shortened_string = ?",
+		shortenedString,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *sqliteRepository) ListUserLinks(
+	ctx context.Context,
+	username string,
+	offset int,
+	limit int,
+) ([]*domain.Link, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT "+linkColumns+" FROM links WHERE username = ?1 ORDER BY created_at DESC, shortened_string LIMIT ?3 OFFSET ?2",
+		username,
+		offset,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*domain.Link, 0)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *sqliteRepository) ListUserLinksAfter(
+	ctx context.Context,
+	username string,
+	after *domain.Link,
+	limit int,
+) ([]*domain.Link, error) {
+	if after == nil {
+		return r.ListUserLinks(ctx, username, 0, limit)
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+linkColumns+` FROM links
+		WHERE username = ?1 AND (created_at < ?2 OR created_at = ?2 AND shortened_string > ?3)
+		ORDER BY created_at DESC, shortened_string LIMIT ?4`,
+		username,
+		after.CreatedAt.UTC(),
+		after.ShortenedString,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*domain.Link, 0)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *sqliteRepository) CountUserLinks(
+	ctx context.Context,
+	username string,
+) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT count(*) FROM links WHERE username = ?",
+		username,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *sqliteRepository) GetUser(
+	ctx context.Context,
+	username string,
+) (*domain.User, error) {
+	user := new(domain.User)
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT username, password FROM users WHERE username = ?",
+		username,
+	).Scan(&user.Username, &user.Password)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain_errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *sqliteRepository) CreateUser(
+	ctx context.Context,
+	user *domain.User,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO users (username, password) VALUES (?, ?) ON CONFLICT (username) DO NOTHING",
+		user.Username,
+		user.Password,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUsernameTaken
+	}
+	return nil
+}
+
+func (r *sqliteRepository) UpdateUserPassword(
+	ctx context.Context,
+	username string,
+	password string,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE users SET password = ?2 WHERE username = ?1",
+		username,
+		password,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUserNotFound
+	}
+	return nil
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (*domain.APIKey, error) {
+	apiKey := new(domain.APIKey)
+	err := row.Scan(
+		&apiKey.Prefix,
+		&apiKey.Hash,
+		&apiKey.Name,
+		&apiKey.Username,
+		&apiKey.CreatedAt,
+		&apiKey.LastUsedAt,
+		&apiKey.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+func (r *sqliteRepository) GetAPIKey(
+	ctx context.Context,
+	prefix string,
+) (*domain.APIKey, error) {
+	apiKey, err := scanAPIKey(r.db.QueryRowContext(
+		ctx,
+		"SELECT prefix, hash, name, username, created_at, last_used_at, expires_at FROM api_keys WHERE prefix = ?",
+		prefix,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain_errors.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (r *sqliteRepository) ListAPIKeys(
+	ctx context.Context,
+	username string,
+) ([]*domain.APIKey, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT prefix, hash, name, username, created_at, last_used_at, expires_at FROM api_keys WHERE username = ? ORDER BY created_at, prefix",
+		username,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := make([]*domain.APIKey, 0)
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (r *sqliteRepository) CreateAPIKey(
+	ctx context.Context,
+	apiKey *domain.APIKey,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO api_keys (prefix, hash, name, username, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (prefix) DO NOTHING",
+		apiKey.Prefix,
+		apiKey.Hash,
+		apiKey.Name,
+		apiKey.Username,
+		apiKey.CreatedAt.UTC(),
+		utcTime(apiKey.ExpiresAt),
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrUsedAPIKeyPrefix
+	}
+	return nil
+}
+
+func (r *sqliteRepository) DeleteAPIKey(
+	ctx context.Context,
+	username string,
+	prefix string,
+) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM api_keys WHERE username = ? AND prefix = ?",
+		username,
+		prefix,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain_errors.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *sqliteRepository) UpdateAPIKeyLastUsedAt(
+	ctx context.Context,
+	prefix string,
+	lastUsedAt time.Time,
+) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"UPDATE api_keys SET last_used_at = ?2 WHERE prefix = ?1",
+		prefix,
+		lastUsedAt.UTC(),
+	)
+	return err
+}
+
+func (r *sqliteRepository) NextIDBlock(ctx context.Context) (int64, error) {
+	// the write lock taken by update serializes concurrent callers
+	var block int64
+	err := r.db.QueryRowContext(
+		ctx,
+		"UPDATE short_code_block_seq SET value = value + 1 RETURNING value",
+	).Scan(&block)
+	return block, err
+}
+
+func (r *sqliteRepository) CreateClicks(
+	ctx context.Context,
+	clicks []*domain.Click,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO clicks (shortened_string, referrer, user_agent, user_agent_family, ip_hash, clicked_at)
+		SELECT ?1, ?2, ?3, ?4, ?5, ?6
+		WHERE EXISTS (SELECT 1 FROM links WHERE shortened_string = ?1)`,
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, click := range clicks {
+		_, err = stmt.ExecContext(
+			ctx,
+			click.ShortenedString,
+			click.Referrer,
+			click.UserAgent,
+			click.UserAgentFamily,
+			click.IPHash,
+			click.ClickedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	shortenedStrings, counts := uncountedClicks(clicks)
+	for _, shortenedString := range shortenedStrings {
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE links SET click_count = click_count + ?2 WHERE shortened_string = ?1",
+			shortenedString,
+			counts[shortenedString],
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *sqliteRepository) CountClicks(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+) (int, int, error) {
+	var clicks, uniqueVisitors int
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT count(*), count(DISTINCT ip_hash) FROM clicks
+		WHERE shortened_string = ? AND clicked_at >= ? AND clicked_at < ?`,
+		shortenedString,
+		from.UTC(),
+		to.UTC(),
+	).Scan(&clicks, &uniqueVisitors)
+	if err != nil {
+		return 0, 0, err
+	}
+	return clicks, uniqueVisitors, nil
+}
+
+// sqliteBucketStarts truncate clicked_at like domain.StatsInterval.Truncate;
+// 'weekday 0' moves to the next sunday unless already on one so going back
+// 6 days lands on monday
+var sqliteBucketStarts = map[domain.StatsInterval]string{
+	domain.StatsIntervalHour: "strftime('%Y-%m-%d %H:00:00', clicked_at)",
+	domain.StatsIntervalDay:  "strftime('%Y-%m-%d 00:00:00', clicked_at)",
+	domain.StatsIntervalWeek: "strftime('%Y-%m-%d 00:00:00', clicked_at, 'weekday 0', '-6 days')",
+}
+
+func (r *sqliteRepository) ListClickBuckets(
+	ctx context.Context,
+	shortenedString string,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) ([]*domain.StatsBucket, error) {
+	bucketStart, exists := sqliteBucketStarts[interval]
+	if !exists {
+		return nil, errors.New("repository: invalid stats interval " + string(interval))
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+bucketStart+` AS start, count(*), count(DISTINCT ip_hash)
+		FROM clicks
+		WHERE shortened_string = ? AND clicked_at >= ? AND clicked_at < ?
+		GROUP BY start
+		ORDER BY start`,
+		shortenedString,
+		from.UTC(),
+		to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]*domain.StatsBucket, 0)
+	for rows.Next() {
+		var start string
+		bucket := new(domain.StatsBucket)
+		err = rows.Scan(&start, &bucket.Clicks, &bucket.UniqueVisitors)
+		if err != nil {
+			return nil, err
+		}
+		bucket.Start, err = time.Parse("2006-01-02 15:04:05", start)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func (r *sqliteRepository) ListTopReferrers(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	return r.listTopClickValues(ctx, "referrer", shortenedString, from, to, limit)
+}
+
+func (r *sqliteRepository) ListTopUserAgentFamilies(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	return r.listTopClickValues(ctx, "user_agent_family", shortenedString, from, to, limit)
+}
+
+// listTopClickValues counts non empty values of a clicks column, column is
+// never user input
+func (r *sqliteRepository) listTopClickValues(
+	ctx context.Context,
+	column string,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+column+`, count(*) AS count FROM clicks
+		WHERE shortened_string = ? AND clicked_at >= ? AND clicked_at < ?
+			AND `+column+` <> ''
+		GROUP BY `+column+`
+		ORDER BY count DESC, `+column+`
+		LIMIT ?`,
+		shortenedString,
+		from.UTC(),
+		to.UTC(),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]*domain.StatsCount, 0)
+	for rows.Next() {
+		count := new(domain.StatsCount)
+		err = rows.Scan(&count.Value, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,44 @@
+package repository_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository/repositorytest"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSQLite returns a migrated database in a temporary file
+func setupSQLite(t *testing.T) *sql.DB {
+	require := require.New(t)
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") +
+		"?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate"
+	db, err := sql.Open("sqlite3", dsn)
+	require.NoError(err)
+	t.Cleanup(func() { db.Close() })
+
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	require.NoError(err)
+	migrator, err := migrate.NewWithDatabaseInstance(
+		"file://../../migrations-sqlite",
+		"sqlite3", driver,
+	)
+	require.NoError(err)
+	err = migrator.Up()
+	require.NoError(err)
+
+	return db
+}
+
+func TestSQLiteRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) port.Repository {
+		return repository.NewSQLiteRepository(setupSQLite(t))
+	})
+}
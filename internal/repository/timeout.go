@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+)
+
+// timeoutRepository bounds every call to the wrapped repository by a
+// timeout on top of the caller's context
+type timeoutRepository struct {
+	repo    port.Repository
+	timeout time.Duration
+}
+
+// NewTimeoutRepository returns repo as is if timeout is not positive
+func NewTimeoutRepository(repo port.Repository, timeout time.Duration) port.Repository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutRepository{repo: repo, timeout: timeout}
+}
+
+var _ port.Repository = (*timeoutRepository)(nil)
+
+func (r *timeoutRepository) GetLink(
+	ctx context.Context,
+	shortenedString string,
+) (*domain.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.GetLink(ctx, shortenedString)
+}
+
+func (r *timeoutRepository) RegisterLinkClick(
+	ctx context.Context,
+	shortenedString string,
+	now time.Time,
+) (*domain.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.RegisterLinkClick(ctx, shortenedString, now)
+}
+
+func (r *timeoutRepository) CreateLink(ctx context.Context, link *domain.Link) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CreateLink(ctx, link)
+}
+
+func (r *timeoutRepository) CreateLinks(
+	ctx context.Context,
+	links []*domain.Link,
+) ([]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CreateLinks(ctx, links)
+}
+
+func (r *timeoutRepository) UpdateLink(ctx context.Context, link *domain.Link) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.UpdateLink(ctx, link)
+}
+
+func (r *timeoutRepository) DeleteLink(ctx context.Context, shortenedString string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.DeleteLink(ctx, shortenedString)
+}
+
+func (r *timeoutRepository) ListUserLinks(
+	ctx context.Context,
+	username string,
+	offset int,
+	limit int,
+) ([]*domain.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.ListUserLinks(ctx, username, offset, limit)
+}
+
+func (r *timeoutRepository) ListUserLinksAfter(
+	ctx context.Context,
+	username string,
+	after *domain.Link,
+	limit int,
+) ([]*domain.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.ListUserLinksAfter(ctx, username, after, limit)
+}
+
+func (r *timeoutRepository) CountUserLinks(ctx context.Context, username string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CountUserLinks(ctx, username)
+}
+
+func (r *timeoutRepository) GetUser(ctx context.Context, username string) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.GetUser(ctx, username)
+}
+
+func (r *timeoutRepository) CreateUser(ctx context.Context, user *domain.User) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CreateUser(ctx, user)
+}
+
+func (r *timeoutRepository) UpdateUserPassword(
+	ctx context.Context,
+	username string,
+	password string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.UpdateUserPassword(ctx, username, password)
+}
+
+func (r *timeoutRepository) GetAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.GetAPIKey(ctx, prefix)
+}
+
+func (r *timeoutRepository) ListAPIKeys(
+	ctx context.Context,
+	username string,
+) ([]*domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.ListAPIKeys(ctx, username)
+}
+
+func (r *timeoutRepository) CreateAPIKey(ctx context.Context, apiKey *domain.APIKey) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CreateAPIKey(ctx, apiKey)
+}
+
+func (r *timeoutRepository) DeleteAPIKey(
+	ctx context.Context,
+	username string,
+	prefix string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.DeleteAPIKey(ctx, username, prefix)
+}
+
+func (r *timeoutRepository) UpdateAPIKeyLastUsedAt(
+	ctx context.Context,
+	prefix string,
+	lastUsedAt time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.UpdateAPIKeyLastUsedAt(ctx, prefix, lastUsedAt)
+}
+
+func (r *timeoutRepository) NextIDBlock(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.NextIDBlock(ctx)
+}
+
+func (r *timeoutRepository) CreateClicks(ctx context.Context, clicks []*domain.Click) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CreateClicks(ctx, clicks)
+}
+
+func (r *timeoutRepository) CountClicks(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+) (int, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.CountClicks(ctx, shortenedString, from, to)
+}
+
+func (r *timeoutRepository) ListClickBuckets(
+	ctx context.Context,
+	shortenedString string,
+	interval domain.StatsInterval,
+	from time.Time,
+	to time.Time,
+) ([]*domain.StatsBucket, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.ListClickBuckets(ctx, shortenedString, interval, from, to)
+}
+
+func (r *timeoutRepository) ListTopReferrers(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.ListTopReferrers(ctx, shortenedString, from, to, limit)
+}
+
+func (r *timeoutRepository) ListTopUserAgentFamilies(
+	ctx context.Context,
+	shortenedString string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]*domain.StatsCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.repo.ListTopUserAgentFamilies(ctx, shortenedString, from, to, limit)
+}
@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository/repositorytest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) port.Repository {
+		return repository.NewTimeoutRepository(
+			repository.NewMemoryRepository(),
+			time.Second,
+		)
+	})
+}
+
+// blockUntilDone stands for a query which only returns when its context is
+// done, as database/sql does for a cancelled query
+func blockUntilDone(ctx context.Context, _ string) (*domain.Link, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTimeoutRepositoryAbortsSlowCall(t *testing.T) {
+	require := require.New(t)
+	mock := mockups.NewMockRepository(gomock.NewController(t))
+	mock.EXPECT().
+		GetLink(gomock.Any(), "shortened_string").
+		DoAndReturn(blockUntilDone)
+
+	repo := repository.NewTimeoutRepository(mock, 10*time.Millisecond)
+
+	start := time.Now()
+	link, err := repo.GetLink(context.Background(), "shortened_string")
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Nil(link)
+	require.Less(time.Since(start), time.Second)
+}
+
+func TestTimeoutRepositoryPropagatesCancel(t *testing.T) {
+	require := require.New(t)
+	mock := mockups.NewMockRepository(gomock.NewController(t))
+	mock.EXPECT().
+		GetLink(gomock.Any(), "shortened_string").
+		DoAndReturn(blockUntilDone)
+
+	repo := repository.NewTimeoutRepository(mock, time.Hour)
+
+	// cancel the request while the call is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	link, err := repo.GetLink(ctx, "shortened_string")
+	require.ErrorIs(err, context.Canceled)
+	require.Nil(link)
+}
+
+func TestNewTimeoutRepositoryWithoutTimeout(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	require.Equal(t, repo, repository.NewTimeoutRepository(repo, 0))
+}
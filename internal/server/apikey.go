@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/validate"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) CreateApiKey(c echo.Context) error {
+	// parse and validate name and expiration
+	var body oapi.CreateAPIKeyRequestBody
+	if httpError := (&echo.DefaultBinder{}).BindBody(c, &body); httpError != nil {
+		return httpError
+	}
+	if err := validate.CreateAPIKeyRequestBody(body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	apiKey, key, err := s.serviceUseCases.CreateAPIKey(
+		c.Request().Context(),
+		user,
+		body.Name,
+		body.ExpiresAt,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	return c.JSON(http.StatusOK, oapi.CreateAPIKeyResponseBody{
+		Key: key,
+		ApiKey: oapi.APIKey{
+			Prefix:     apiKey.Prefix,
+			Name:       apiKey.Name,
+			CreatedAt:  apiKey.CreatedAt,
+			LastUsedAt: apiKey.LastUsedAt,
+			ExpiresAt:  apiKey.ExpiresAt,
+		},
+	})
+}
+
+func (s *Server) ListApiKeys(c echo.Context) error {
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := s.serviceUseCases.ListAPIKeys(c.Request().Context(), user)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	response := oapi.ListAPIKeysResponseBody{
+		ApiKeys: make([]oapi.APIKey, 0, len(apiKeys)),
+	}
+	for _, apiKey := range apiKeys {
+		response.ApiKeys = append(response.ApiKeys, oapi.APIKey{
+			Prefix:     apiKey.Prefix,
+			Name:       apiKey.Name,
+			CreatedAt:  apiKey.CreatedAt,
+			LastUsedAt: apiKey.LastUsedAt,
+			ExpiresAt:  apiKey.ExpiresAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (s *Server) RevokeApiKey(
+	c echo.Context,
+	apiKeyPrefix oapi.ApiKeyPrefix,
+) error {
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	err = s.serviceUseCases.RevokeAPIKey(c.Request().Context(), user, apiKeyPrefix)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/auth"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// authenticatedUser returns the principal the request validator resolved off
+// the operation's security requirements
+func authenticatedUser(c echo.Context) (*domain.User, error) {
+	user, ok := auth.UserFromContext(c)
+	if !ok {
+		return nil, echo.NewHTTPError(
+			http.StatusUnauthorized,
+			"authorization not provided",
+		)
+	}
+	return user, nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/validate"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) CreateLinksBatch(c echo.Context) error {
+	// parse and validate every link
+	var body oapi.CreateLinksBatchRequestBody
+	if httpError := (&echo.DefaultBinder{}).BindBody(c, &body); httpError != nil {
+		return httpError
+	}
+	if err := validate.CreateLinksBatchRequestBody(body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	atomic := body.Atomic != nil && *body.Atomic
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	links := make([]*domain.Link, 0, len(body.Links))
+	for _, item := range body.Links {
+		link := &domain.Link{URL: item.Url}
+		if item.ShortenedString != nil {
+			link.ShortenedString = *item.ShortenedString
+		}
+		links = append(links, link)
+	}
+
+	results, err := s.serviceUseCases.CreateLinks(
+		c.Request().Context(),
+		links,
+		user,
+		atomic,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	response := oapi.CreateLinksBatchResponseBody{
+		Results: make([]oapi.BatchLinkResult, 0, len(results)),
+	}
+	for i, result := range results {
+		item := oapi.BatchLinkResult{Url: body.Links[i].Url}
+		if result.Err != nil {
+			message := batchLinkError(c, result.Err)
+			item.Error = &message
+		} else {
+			item.Created = true
+			item.ShortenedString = &result.Link.ShortenedString
+			response.Created++
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	if atomic && response.Created == 0 {
+		return c.JSON(http.StatusUnprocessableEntity, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// batchLinkError describes why a link of a batch is not created, unhandled
+// errors are logged instead of exposed
+func batchLinkError(c echo.Context, err error) string {
+	message, ok := httperror.Message(err, "link not created")
+	if !ok {
+		c.Logger().Error(err)
+	}
+	return message
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/labstack/echo/v4"
+)
+
+// exportCSVHeader are the columns of csv exports, the ones import reads are
+// named the same
+var exportCSVHeader = []string{
+	"shortened_string",
+	"url",
+	"created_at",
+	"expires_at",
+	"max_clicks",
+	"click_count",
+	"redirect_type",
+	"password_protected",
+}
+
+func (s *Server) ExportLinks(c echo.Context, params oapi.ExportLinksParams) error {
+	format := oapi.ExportLinksParamsFormatCsv
+	if params.Format != nil {
+		format = *params.Format
+	}
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	// the response is only committed once the buffer fills, so errors
+	// reading the first links still get an error response
+	res := c.Response()
+	buffer := bufio.NewWriter(res)
+	var write func(link *domain.Link) error
+	var flush func() error
+	switch format {
+	case oapi.ExportLinksParamsFormatJson:
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="links.json"`)
+		write, flush = jsonLinksWriter(buffer)
+	default:
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="links.csv"`)
+		write, flush = csvLinksWriter(buffer)
+	}
+
+	err = s.serviceUseCases.ExportLinks(c.Request().Context(), user, write)
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		if !res.Committed {
+			res.Header().Del(echo.HeaderContentDisposition)
+			return httperror.New(err)
+		}
+		// the status is already sent, the client gets a truncated file
+		c.Logger().Error(err)
+	}
+	return nil
+}
+
+// jsonLinksWriter writes links as a json array
+func jsonLinksWriter(
+	buffer *bufio.Writer,
+) (write func(link *domain.Link) error, flush func() error) {
+	encoder := json.NewEncoder(buffer)
+	count := 0
+	write = func(link *domain.Link) error {
+		separator := ","
+		if count == 0 {
+			separator = "["
+		}
+		count++
+		if _, err := buffer.WriteString(separator); err != nil {
+			return err
+		}
+		return encoder.Encode(toLink(link))
+	}
+	flush = func() error {
+		end := "]\n"
+		if count == 0 {
+			end = "[]\n"
+		}
+		if _, err := buffer.WriteString(end); err != nil {
+			return err
+		}
+		return buffer.Flush()
+	}
+	return write, flush
+}
+
+// csvLinksWriter writes links as csv rows after a header row
+func csvLinksWriter(
+	buffer *bufio.Writer,
+) (write func(link *domain.Link) error, flush func() error) {
+	writer := csv.NewWriter(buffer)
+	headerWritten := false
+	writeHeader := func() error {
+		if headerWritten {
+			return nil
+		}
+		headerWritten = true
+		return writer.Write(exportCSVHeader)
+	}
+	write = func(link *domain.Link) error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+		return writer.Write(csvLinkRow(link))
+	}
+	flush = func() error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		return buffer.Flush()
+	}
+	return write, flush
+}
+
+// csvLinkRow returns the cells of link in exportCSVHeader order, cells of
+// unset fields are empty
+func csvLinkRow(link *domain.Link) []string {
+	row := []string{
+		link.ShortenedString,
+		link.URL,
+		link.CreatedAt.UTC().Format(time.RFC3339),
+		"",
+		"",
+		strconv.Itoa(link.ClickCount),
+		"",
+		strconv.FormatBool(link.Protected()),
+	}
+	if link.ExpiresAt != nil {
+		row[3] = link.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if link.MaxClicks != nil {
+		row[4] = strconv.Itoa(*link.MaxClicks)
+	}
+	if link.RedirectType != nil {
+		row[6] = strconv.Itoa(int(*link.RedirectType))
+	}
+	return row
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) GetLiveness(c echo.Context) error {
+	// answering at all is enough to tell the process is alive
+	return c.JSON(http.StatusOK, oapi.HealthResponseBody{
+		Status:     oapi.Ok,
+		Components: map[string]oapi.ComponentHealth{},
+	})
+}
+
+func (s *Server) GetReadiness(c echo.Context) error {
+	results, ready := s.health.Ready(c.Request().Context())
+
+	body := oapi.HealthResponseBody{
+		Status:     oapi.Ok,
+		Components: make(map[string]oapi.ComponentHealth, len(results)),
+	}
+	for name, err := range results {
+		component := oapi.ComponentHealth{Status: oapi.Ok}
+		if err != nil {
+			// the details are logged, not told to anonymous callers
+			c.Logger().Errorf("readiness: %s: %s", name, err)
+			component.Status = oapi.Failing
+			component.Error = new(string)
+			*component.Error = health.Describe(err)
+		}
+		body.Components[name] = component
+	}
+
+	status := http.StatusOK
+	if !ready {
+		body.Status = oapi.Failing
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, body)
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/auth"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/validate"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// maxImportRows bounds the rows of an imported file
+	maxImportRows = 10000
+	// maxImportBytes bounds the body of an import request
+	maxImportBytes = 16 << 20
+)
+
+var errTooManyImportRows = fmt.Errorf("file has more than %d rows", maxImportRows)
+
+// limitImportBody bounds import bodies before anything reads them, as the
+// request validator reads bodies whole
+func limitImportBody(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().URL.Path == "/links/import" {
+			c.Request().Body = http.MaxBytesReader(
+				c.Response(),
+				c.Request().Body,
+				maxImportBytes,
+			)
+		}
+		return next(c)
+	}
+}
+
+// validationErrorHandler reports bodies over their limit as too large, the
+// rest are left to auth.ErrorHandler
+func validationErrorHandler(c echo.Context, err *echo.HTTPError) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err.Internal, &maxBytesErr) {
+		return echo.NewHTTPError(
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit),
+		).SetInternal(err.Internal)
+	}
+	return auth.ErrorHandler(c, err)
+}
+
+// importRow is a link to create read from a row, or why the row can't be read
+type importRow struct {
+	body oapi.CreateLinkRequestBody
+	err  error
+}
+
+func (s *Server) ImportLinks(c echo.Context, params oapi.ImportLinksParams) error {
+	format := oapi.Csv
+	if params.Format != nil {
+		format = *params.Format
+	}
+
+	// parse the file, rows are validated on their own
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).
+			SetInternal(err)
+	}
+	defer file.Close()
+
+	var rows []importRow
+	switch format {
+	case oapi.Json:
+		rows, err = readJSONImportRows(file)
+	default:
+		rows, err = readCSVImportRows(file)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	response := oapi.ImportLinksResponseBody{
+		Results: make([]oapi.ImportRowResult, len(rows)),
+	}
+	links := make([]*domain.Link, 0, len(rows))
+	// indexes of the rows of links
+	indexes := make([]int, 0, len(rows))
+	for i, row := range rows {
+		response.Results[i].Row = i + 1
+		if row.err == nil {
+			row.err = validate.ImportedLink(row.body)
+		}
+		if row.err != nil {
+			message := row.err.Error()
+			response.Results[i].Error = &message
+			continue
+		}
+		links = append(links, toDomainLink(row.body))
+		indexes = append(indexes, i)
+	}
+
+	if len(links) > 0 {
+		results, err := s.serviceUseCases.CreateLinks(
+			c.Request().Context(),
+			links,
+			user,
+			false,
+		)
+		if err != nil {
+			return httperror.New(err)
+		}
+		for j, result := range results {
+			item := &response.Results[indexes[j]]
+			if result.Err != nil {
+				message := batchLinkError(c, result.Err)
+				item.Error = &message
+				continue
+			}
+			item.Created = true
+			item.ShortenedString = &result.Link.ShortenedString
+			response.Created++
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// readJSONImportRows reads an array of link objects one at a time, so a
+// file of too many rows is rejected without decoding the rest
+func readJSONImportRows(r io.Reader) ([]importRow, error) {
+	errNotArray := errors.New("file is not a json array")
+
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errNotArray
+	}
+	var rows []importRow
+	for decoder.More() {
+		if len(rows) == maxImportRows {
+			return nil, errTooManyImportRows
+		}
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return nil, errNotArray
+		}
+		var row importRow
+		row.err = json.Unmarshal(element, &row.body)
+		rows = append(rows, row)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, errNotArray
+	}
+	return rows, nil
+}
+
+// readCSVImportRows reads rows by the column names of the header row
+func readCSVImportRows(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	// rows with missing cells are reported on their own
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file has no header row")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, exists := columns["url"]; !exists {
+		return nil, errors.New("file has no url column")
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyImportRows
+		}
+		if len(record) != len(header) {
+			rows = append(rows, importRow{err: fmt.Errorf(
+				"row has %d cells but the header has %d",
+				len(record),
+				len(header),
+			)})
+			continue
+		}
+		body, err := parseCSVImportRow(columns, record)
+		rows = append(rows, importRow{body: body, err: err})
+	}
+}
+
+// parseCSVImportRow returns the fields of record, empty cells are unset
+func parseCSVImportRow(
+	columns map[string]int,
+	record []string,
+) (oapi.CreateLinkRequestBody, error) {
+	cell := func(name string) (string, bool) {
+		i, exists := columns[name]
+		if !exists || record[i] == "" {
+			return "", false
+		}
+		return record[i], true
+	}
+
+	var body oapi.CreateLinkRequestBody
+	body.Url, _ = cell("url")
+	if value, ok := cell("shortened_string"); ok {
+		body.ShortenedString = &value
+	}
+	if value, ok := cell("password"); ok {
+		body.Password = &value
+	}
+	if value, ok := cell("expires_at"); ok {
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return body, errors.New("expires_at: must be a RFC 3339 time")
+		}
+		body.ExpiresAt = &expiresAt
+	}
+	if value, ok := cell("max_clicks"); ok {
+		maxClicks, err := strconv.Atoi(value)
+		if err != nil {
+			return body, errors.New("max_clicks: must be an integer")
+		}
+		body.MaxClicks = &maxClicks
+	}
+	if value, ok := cell("redirect_type"); ok {
+		redirectType, err := strconv.Atoi(value)
+		if err != nil {
+			return body, errors.New("redirect_type: must be an integer")
+		}
+		body.RedirectType = (*oapi.RedirectType)(&redirectType)
+	}
+	return body, nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+// html/template escapes every value by the context it's rendered in
+var previewTemplate = template.Must(
+	template.ParseFS(templatesFS, "templates/preview.html"),
+)
+
+type previewData struct {
+	ShortenedString string
+	// URL is hidden if Protected
+	URL       string
+	Protected bool
+	Username  string
+	CreatedAt time.Time
+	// FollowPath redirects through the server so the click is counted
+	FollowPath string
+}
+
+func (s *Server) PreviewLink(
+	c echo.Context,
+	shortenedString oapi.ShortenedString,
+) error {
+	link, err := s.serviceUseCases.PreviewLink(
+		c.Request().Context(),
+		shortenedString,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	user, err := s.serviceUseCases.GetLinkUser(
+		c.Request().Context(),
+		shortenedString,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	var page bytes.Buffer
+	err = previewTemplate.Execute(&page, previewData{
+		ShortenedString: link.ShortenedString,
+		URL:             previewURL(link),
+		Protected:       link.Protected(),
+		Username:        user.Username,
+		CreatedAt:       link.CreatedAt.UTC(),
+		FollowPath:      s.linkPath(link.ShortenedString),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).
+			SetInternal(err)
+	}
+
+	return c.HTMLBlob(http.StatusOK, page.Bytes())
+}
+
+// previewURL keeps the url of protected links secret
+func previewURL(link *domain.Link) string {
+	if link.Protected() {
+		return ""
+	}
+	return link.URL
+}
+
+// linkPath follows the link of shortenedString, it's absolute if a base url
+// is given
+func (s *Server) linkPath(shortenedString string) string {
+	return s.baseURL + "/link/" + url.PathEscape(shortenedString)
+}
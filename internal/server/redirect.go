@@ -0,0 +1,79 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+)
+
+// maxRedirectAge bounds how long clients may cache permanent redirects
+const maxRedirectAge = 365 * 24 * time.Hour
+
+// redirectType returns the redirect type of link or the server default
+func (s *Server) redirectType(link *domain.Link) domain.RedirectType {
+	if link.RedirectType != nil {
+		return *link.RedirectType
+	}
+	return s.defaultRedirectType
+}
+
+// redirectCacheControl lets clients cache permanent redirects until the link
+// expires. temporary redirects, links with max clicks and protected links are
+// never cached as every click should reach the server
+func redirectCacheControl(
+	link *domain.Link,
+	redirectType domain.RedirectType,
+	now time.Time,
+) string {
+	if !redirectType.Permanent() || link.MaxClicks != nil || link.Protected() {
+		return "no-store"
+	}
+	maxAge := maxRedirectAge
+	if link.ExpiresAt != nil && link.ExpiresAt.Sub(now) < maxAge {
+		maxAge = link.ExpiresAt.Sub(now)
+	}
+	if maxAge < time.Second {
+		return "no-store"
+	}
+	return fmt.Sprintf("public, max-age=%d", int64(maxAge/time.Second))
+}
+
+func toDomainRedirectType(redirectType *oapi.RedirectType) *domain.RedirectType {
+	if redirectType == nil {
+		return nil
+	}
+	t := domain.RedirectType(*redirectType)
+	return &t
+}
+
+func toOAPIRedirectType(redirectType *domain.RedirectType) *oapi.RedirectType {
+	if redirectType == nil {
+		return nil
+	}
+	t := oapi.RedirectType(*redirectType)
+	return &t
+}
+
+// updateLinkRequestBody tells an explicit null redirect type, resetting the
+// link to the server default, from an absent one keeping it
+type updateLinkRequestBody struct {
+	oapi.UpdateLinkRequestBody
+	RedirectType nullableRedirectType `json:"redirect_type"`
+}
+
+type nullableRedirectType struct {
+	value *oapi.RedirectType
+	null  bool
+}
+
+func (t *nullableRedirectType) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.null = true
+		return nil
+	}
+	return json.Unmarshal(data, &t.value)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/auth"
+	"github.com/aria3ppp/url-shortener-openapi/internal/metrics"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/deepmap/oapi-codegen/pkg/middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/labstack/echo/v4"
+)
+
+// unvalidatedPaths are neither validated nor authenticated, the probes of
+// the spec must answer whatever the request and the rest are not part of it
+var unvalidatedPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// NewRouter returns the echo serving the api of s behind the request
+// validator, which authenticates requests by authenticator, and the metrics
+// of m collected off every request. client ips are the connection peers
+// unless they're trustedProxies, then they're taken off X-Forwarded-For
+func NewRouter(
+	s *Server,
+	authenticator *auth.Authenticator,
+	m *metrics.Metrics,
+	trustedProxies []*net.IPNet,
+) (*echo.Echo, error) {
+	swagger, err := oapi.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	// match requests of any host, the spec server is only an example
+	swagger.Servers = nil
+
+	e := echo.New()
+	e.IPExtractor = ipExtractor(trustedProxies)
+	e.Use(metrics.Middleware(m, swagger))
+	e.Use(limitImportBody)
+	e.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		Skipper: func(c echo.Context) bool {
+			return unvalidatedPaths[c.Request().URL.Path]
+		},
+		ErrorHandler: validationErrorHandler,
+		Options: openapi3filter.Options{
+			AuthenticationFunc: authenticator.Authenticate,
+		},
+	}))
+
+	oapi.RegisterHandlers(e, s)
+	e.GET("/metrics", echo.WrapHandler(m.Handler()))
+
+	return e, nil
+}
+
+// ipExtractor trusts no header unless there're trusted proxies, so clients
+// can't spoof their ip to the password attempt limiter or the clicks
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
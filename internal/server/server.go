@@ -3,23 +3,46 @@ package server
 import (
 	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
 	domain_errors "github.com/aria3ppp/url-shortener-openapi/internal/core/errors"
 	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
 	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
 	"github.com/aria3ppp/url-shortener-openapi/internal/validate"
 	"github.com/labstack/echo/v4"
 )
 
 type Server struct {
-	serviceUseCases port.ServiceUseCases
+	serviceUseCases     port.ServiceUseCases
+	clickRecorder       port.ClickRecorder
+	defaultRedirectType domain.RedirectType
+	health              *health.Checker
+	// baseURL prefixes the paths pages link to
+	baseURL  string
+	attempts attemptLimiters
 }
 
 var _ oapi.ServerInterface = &Server{}
 
-func New(serviceUseCases port.ServiceUseCases) *Server {
-	return &Server{serviceUseCases: serviceUseCases}
+func New(
+	serviceUseCases port.ServiceUseCases,
+	clickRecorder port.ClickRecorder,
+	defaultRedirectType domain.RedirectType,
+	health *health.Checker,
+	baseURL string,
+) *Server {
+	return &Server{
+		serviceUseCases:     serviceUseCases,
+		clickRecorder:       clickRecorder,
+		defaultRedirectType: defaultRedirectType,
+		health:              health,
+		baseURL:             strings.TrimSuffix(baseURL, "/"),
+		attempts:            newAttemptLimiters(),
+	}
 }
 
 func (s *Server) CreateLink(c echo.Context) error {
@@ -31,77 +54,249 @@ func (s *Server) CreateLink(c echo.Context) error {
 	if err := validate.CreateLinkRequestBody(body); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	if body.ShortenedString == nil {
-		body.ShortenedString = new(string)
-		*body.ShortenedString = ""
-	}
 
-	// fetch username:password off the basic authorization
-	username, password, ok := c.Request().BasicAuth()
-	if !ok {
-		return echo.NewHTTPError(
-			http.StatusUnauthorized,
-			"basic authorization not provided",
-		)
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
 	}
 
 	link, err := s.serviceUseCases.CreateLink(
-		body.Url,
-		*body.ShortenedString,
-		&domain.User{Username: username, Password: password},
+		c.Request().Context(),
+		toDomainLink(body),
+		user,
 	)
 	if err != nil {
-		if errors.Is(err, domain_errors.ErrUserNotFound) ||
-			errors.Is(err, domain_errors.ErrIncorrectPassword) {
-			return echo.NewHTTPError(
-				http.StatusUnauthorized,
-				"invalid username or password",
-			)
-		}
-		if errors.Is(err, domain_errors.ErrUsedShortenedString) {
-			return echo.NewHTTPError(
-				http.StatusConflict,
-				"shortened string have used",
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError).
-			SetInternal(err)
+		return httperror.New(err)
 	}
 
 	return c.JSON(http.StatusOK, oapi.CreateLinkResponseBody{
-		ShortenedString: link.ShortenedString,
-		Url:             link.URL,
-		Username:        link.Username,
+		ShortenedString:   link.ShortenedString,
+		Url:               link.URL,
+		Username:          link.Username,
+		ExpiresAt:         link.ExpiresAt,
+		MaxClicks:         link.MaxClicks,
+		RedirectType:      toOAPIRedirectType(link.RedirectType),
+		PasswordProtected: link.Protected(),
 	})
 }
 
 func (s *Server) GetLink(
 	c echo.Context,
 	shortenedString oapi.ShortenedString,
+	params oapi.GetLinkParams,
+) error {
+	var password string
+	if params.XLinkPassword != nil {
+		password = *params.XLinkPassword
+	}
+	return s.followLink(c, shortenedString, password, false)
+}
+
+// followLink redirects to the url of a link once its password matches if
+// it's protected. unlock tells the password is submitted by the unlock form
+func (s *Server) followLink(
+	c echo.Context,
+	shortenedString string,
+	password string,
+	unlock bool,
 ) error {
-	link, err := s.serviceUseCases.GetLink(shortenedString)
+	now := time.Now()
+	if password != "" {
+		ok, retryAfter := s.attempts.take(shortenedString, c.RealIP(), now)
+		if !ok {
+			return s.tooManyAttempts(c, shortenedString, retryAfter, unlock)
+		}
+	}
+
+	link, err := s.serviceUseCases.GetLink(
+		c.Request().Context(),
+		shortenedString,
+		password,
+	)
+	if password != "" && !errors.Is(err, domain_errors.ErrIncorrectPassword) {
+		// only failed attempts count
+		s.attempts.giveBack(shortenedString, c.RealIP(), now)
+	}
 	if err != nil {
-		if errors.Is(err, domain_errors.ErrLinkNotFound) {
-			return echo.NewHTTPError(http.StatusNotFound)
+		// locked links respond the unlock form to browsers
+		if errors.Is(err, domain_errors.ErrLinkLocked) {
+			return s.linkLocked(c, shortenedString, "", true)
+		}
+		if errors.Is(err, domain_errors.ErrIncorrectPassword) {
+			return s.linkLocked(
+				c,
+				shortenedString,
+				"incorrect password",
+				unlock,
+			)
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError).
-			SetInternal(err)
+		return httperror.New(err)
 	}
 
-	return c.Redirect(http.StatusPermanentRedirect, link.URL)
+	s.clickRecorder.Record(
+		&domain.Click{
+			ShortenedString: link.ShortenedString,
+			Referrer:        c.Request().Referer(),
+			UserAgent:       c.Request().UserAgent(),
+			ClickedAt:       now,
+			Counted:         link.ClickLimited(),
+		},
+		c.RealIP(),
+	)
+
+	if unlock {
+		// the form is posted so the browser should get the url
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.Redirect(http.StatusSeeOther, link.URL)
+	}
+	redirectType := s.redirectType(link)
+	c.Response().Header().Set(
+		echo.HeaderCacheControl,
+		redirectCacheControl(link, redirectType, now),
+	)
+	return c.Redirect(int(redirectType), link.URL)
+}
+
+func (s *Server) UpdateLink(
+	c echo.Context,
+	shortenedString oapi.ShortenedString,
+) error {
+	// parse and validate url
+	var body updateLinkRequestBody
+	if httpError := (&echo.DefaultBinder{}).BindBody(c, &body); httpError != nil {
+		return httpError
+	}
+	body.UpdateLinkRequestBody.RedirectType = body.RedirectType.value
+	if err := validate.UpdateLinkRequestBody(body.UpdateLinkRequestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	redirectType := toDomainRedirectType(body.RedirectType.value)
+	if body.RedirectType.null {
+		redirectType = new(domain.RedirectType)
+		*redirectType = domain.RedirectDefault
+	}
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	link, err := s.serviceUseCases.UpdateLink(
+		c.Request().Context(),
+		user,
+		shortenedString,
+		body.Url,
+		redirectType,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	return c.JSON(http.StatusOK, toLink(link))
+}
+
+func (s *Server) DeleteLink(
+	c echo.Context,
+	shortenedString oapi.ShortenedString,
+) error {
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	err = s.serviceUseCases.DeleteLink(
+		c.Request().Context(),
+		user,
+		shortenedString,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) ListLinks(c echo.Context, params oapi.ListLinksParams) error {
+	if err := validate.ListLinksParams(params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	page, perPage := 1, 20
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.PerPage != nil {
+		perPage = *params.PerPage
+	}
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	links, total, err := s.serviceUseCases.ListLinks(
+		c.Request().Context(),
+		user,
+		page,
+		perPage,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	response := oapi.ListLinksResponseBody{
+		Links:   make([]oapi.Link, 0, len(links)),
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+	}
+	for _, link := range links {
+		response.Links = append(response.Links, toLink(link))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// toDomainLink returns the link to create from a validated body
+func toDomainLink(body oapi.CreateLinkRequestBody) *domain.Link {
+	link := &domain.Link{
+		URL:          body.Url,
+		ExpiresAt:    body.ExpiresAt,
+		MaxClicks:    body.MaxClicks,
+		RedirectType: toDomainRedirectType(body.RedirectType),
+	}
+	if body.ShortenedString != nil {
+		link.ShortenedString = *body.ShortenedString
+	}
+	if body.Password != nil {
+		link.Password = *body.Password
+	}
+	return link
+}
+
+func toLink(link *domain.Link) oapi.Link {
+	return oapi.Link{
+		ShortenedString:   link.ShortenedString,
+		Url:               link.URL,
+		Username:          link.Username,
+		CreatedAt:         link.CreatedAt,
+		ExpiresAt:         link.ExpiresAt,
+		MaxClicks:         link.MaxClicks,
+		ClickCount:        link.ClickCount,
+		RedirectType:      toOAPIRedirectType(link.RedirectType),
+		PasswordProtected: link.Protected(),
+	}
 }
 
 func (s *Server) GetLinkUser(
 	c echo.Context,
 	shortenedString oapi.ShortenedString,
 ) error {
-	user, err := s.serviceUseCases.GetLinkUser(shortenedString)
+	user, err := s.serviceUseCases.GetLinkUser(
+		c.Request().Context(),
+		shortenedString,
+	)
 	if err != nil {
-		if errors.Is(err, domain_errors.ErrLinkNotFound) {
-			return echo.NewHTTPError(http.StatusNotFound, "link not found")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError).
-			SetInternal(err)
+		return httperror.New(err)
 	}
 
 	return c.JSON(http.StatusOK, oapi.GetLinkUserResponseBody{
@@ -119,19 +314,12 @@ func (s *Server) CreateUser(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	err := s.serviceUseCases.CreateUser(&domain.User{
+	err := s.serviceUseCases.CreateUser(c.Request().Context(), &domain.User{
 		Username: body.Username,
 		Password: body.Password,
 	})
 	if err != nil {
-		if errors.Is(err, domain_errors.ErrUsernameTaken) {
-			return echo.NewHTTPError(
-				http.StatusConflict,
-				"username have taken",
-			)
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError).
-			SetInternal(err)
+		return httperror.New(err)
 	}
 
 	return c.NoContent(http.StatusOK)
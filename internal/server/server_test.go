@@ -0,0 +1,1573 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/auth"
+	"github.com/aria3ppp/url-shortener-openapi/internal/clickrecorder"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port/mockups"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
+	"github.com/aria3ppp/url-shortener-openapi/internal/generator"
+	"github.com/aria3ppp/url-shortener-openapi/internal/hasher"
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	"github.com/aria3ppp/url-shortener-openapi/internal/metrics"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
+	"github.com/aria3ppp/url-shortener-openapi/internal/server"
+	"github.com/gavv/httpexpect/v2"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// setup serves the api as main does and a redirection destination outside
+// of it
+func setup(t *testing.T) (serverURL string, destinationURL string) {
+	return setupWith(t, setupOptions{})
+}
+
+// setupOptions change what setup serves, zero values are its defaults
+type setupOptions struct {
+	generator      port.ShortCodeGenerator
+	trustedProxies []*net.IPNet
+	health         *health.Checker
+}
+
+// setupWith is setup by opts
+func setupWith(
+	t *testing.T,
+	opts setupOptions,
+) (serverURL string, destinationURL string) {
+	if opts.generator == nil {
+		opts.generator = generator.NewRandomStringGenerator(6)
+	}
+	if opts.health == nil {
+		opts.health = health.NewChecker(time.Second)
+	}
+	repository := repository.NewMemoryRepository()
+	generator := opts.generator
+	hasher := hasher.NewBcryptHasher(bcrypt.MinCost)
+	serviceUseCases := usecase.NewService(repository, generator, hasher)
+	clickRecorder := clickrecorder.NewBatchRecorder(
+		repository,
+		[]byte("ip_hash_key"),
+		clickrecorder.DefaultOptions,
+	)
+	t.Cleanup(clickRecorder.Close)
+
+	router, err := server.NewRouter(
+		server.New(
+			serviceUseCases,
+			clickRecorder,
+			domain.RedirectFound,
+			opts.health,
+			"",
+		),
+		auth.NewAuthenticator(serviceUseCases),
+		metrics.New(),
+		opts.trustedProxies,
+	)
+	require.NoError(t, err)
+
+	apiServer := httptest.NewServer(router)
+	t.Cleanup(apiServer.Close)
+
+	destination := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"redirection":"success"}`))
+		},
+	))
+	t.Cleanup(destination.Close)
+
+	return apiServer.URL, destination.URL + "/redirection-destination"
+}
+
+// createUser creates the user every test acts as
+func createUser(e *httpexpect.Expect) oapi.CreateUserRequestBody {
+	user := oapi.CreateUserRequestBody{
+		Username: "username",
+		Password: "password",
+	}
+	e.Request(http.MethodPost, "/user").
+		WithJSON(user).
+		Expect().
+		Status(http.StatusOK).
+		NoContent()
+	return user
+}
+
+func TestGetLink(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// first there's no link
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link not found"})
+
+	// create a new link
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.CreateLinkResponseBody{
+			ShortenedString: linkShortenedString,
+			Url:             url,
+			Username:        user.Username,
+		})
+
+	// the link redirects by the default redirect type
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound).
+		Header("Location").
+		IsEqual(url)
+
+	// get link
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"redirection": "success"})
+
+	// undefined paths are rejected by the request validator
+	e.Request(http.MethodGet, "/undefined/path").
+		Expect().
+		Status(http.StatusBadRequest)
+}
+
+func TestGetLinkUnreservedCharacters(t *testing.T) {
+	// codes of only the url unreserved symbols
+	serverURL, url := setupWith(t, setupOptions{
+		generator: generator.NewSecureRandomStringGenerator(6, "._~-"),
+	})
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	code := e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{Url: url}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("shortened_string").
+		String()
+	code.Match(`^[._~-]{6}$`)
+	shortenedString := code.Raw()
+
+	// the code is routed unescaped and redirects
+	e.Request(http.MethodGet, "/link/"+shortenedString).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound).
+		Header("Location").
+		IsEqual(url)
+
+	e.Request(http.MethodGet, "/link/{shortened_string}/user").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", shortenedString).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.GetLinkUserResponseBody{Username: user.Username})
+}
+
+func TestCreateLink(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// invalid json body
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON("{invalid_json_body}").
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON()
+
+	// empty url, rejected by the request validator
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(map[string]any{}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		String().
+		Contains(`property "url" is missing`)
+
+	// invalid shortened string, rejected by the request validator
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(map[string]string{
+			"shortened_string": "non|alpha|numeric|shortened|string",
+			"url":              url,
+		}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		String().
+		Contains(`Error at "/shortened_string"`)
+
+	// invalid url, the spec uri format accepts what is not a valid url
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(map[string]string{"url": "invalid|url"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		IsEqual(map[string]string{
+			"message": validation.Errors{
+				"url": is.ErrURL,
+			}.Error(),
+		})
+
+	// unauthorized - username and password not provided
+	e.Request(http.MethodPost, "/link").
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "authorization not provided"})
+
+	// unauthorized - user not found
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth("undefined_username", user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "invalid username or password"})
+
+	// unauthorized - incorrect password
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, "incorrect_password").
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "invalid username or password"})
+
+	// create link
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.CreateLinkResponseBody{
+			ShortenedString: linkShortenedString,
+			Url:             url,
+			Username:        user.Username,
+		})
+
+	// assert link is redirected
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"redirection": "success"})
+
+	// shortened string have used
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "shortened string have used"})
+}
+
+func TestGetLinkUser(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// create a new link
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// link not found
+	e.Request(http.MethodGet, "/link/{shortened_string}/user").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "undefinedLink").
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link not found"})
+
+	// get link user
+	e.Request(http.MethodGet, "/link/{shortened_string}/user").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.GetLinkUserResponseBody{Username: user.Username})
+}
+
+func TestManageLinks(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+	otherUser := oapi.CreateUserRequestBody{
+		Username: "other_username",
+		Password: "password",
+	}
+	e.Request(http.MethodPost, "/user").
+		WithJSON(otherUser).
+		Expect().
+		Status(http.StatusOK)
+
+	// create links
+	shortenedStrings := []string{"link01", "link02", "link03"}
+	for i := range shortenedStrings {
+		e.Request(http.MethodPost, "/link").
+			WithBasicAuth(user.Username, user.Password).
+			WithJSON(oapi.CreateLinkRequestBody{
+				ShortenedString: &shortenedStrings[i],
+				Url:             url,
+			}).
+			Expect().
+			Status(http.StatusOK)
+	}
+
+	// list pages of two links
+	for page, length := range map[int]int{1: 2, 2: 1, 3: 0} {
+		response := e.Request(http.MethodGet, "/links").
+			WithBasicAuth(user.Username, user.Password).
+			WithQuery("page", page).
+			WithQuery("per_page", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+		response.Value("links").Array().Length().IsEqual(length)
+		response.Value("page").IsEqual(page)
+		response.Value("per_page").IsEqual(2)
+		response.Value("total").IsEqual(3)
+	}
+
+	// huge pages are empty rather than overflowing the offset
+	e.Request(http.MethodGet, "/links").
+		WithBasicAuth(user.Username, user.Password).
+		WithQuery("page", "4611686018427387904").
+		WithQuery("per_page", 100).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("links").
+		Array().
+		IsEmpty()
+
+	// pagination out of bounds
+	for _, query := range []map[string]int{
+		{"page": 0},
+		{"per_page": 0},
+		{"per_page": 101},
+	} {
+		request := e.Request(http.MethodGet, "/links").
+			WithBasicAuth(user.Username, user.Password)
+		for key, value := range query {
+			request = request.WithQuery(key, value)
+		}
+		request.Expect().Status(http.StatusBadRequest)
+	}
+
+	// other users list none of the links
+	e.Request(http.MethodGet, "/links").
+		WithBasicAuth(otherUser.Username, otherUser.Password).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("total").
+		IsEqual(0)
+
+	// links of another user are forbidden
+	e.Request(http.MethodPatch, "/link/{shortened_string}").
+		WithBasicAuth(otherUser.Username, otherUser.Password).
+		WithPath("shortened_string", "link01").
+		WithJSON(oapi.UpdateLinkRequestBody{Url: url + "/other"}).
+		Expect().
+		Status(http.StatusForbidden).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link is owned by another user"})
+
+	e.Request(http.MethodDelete, "/link/{shortened_string}").
+		WithBasicAuth(otherUser.Username, otherUser.Password).
+		WithPath("shortened_string", "link01").
+		Expect().
+		Status(http.StatusForbidden).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link is owned by another user"})
+
+	// missing links are not found
+	e.Request(http.MethodPatch, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "undefinedLink").
+		WithJSON(oapi.UpdateLinkRequestBody{Url: url}).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link not found"})
+
+	e.Request(http.MethodDelete, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "undefinedLink").
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link not found"})
+
+	// update link
+	e.Request(http.MethodPatch, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "link01").
+		WithJSON(oapi.UpdateLinkRequestBody{Url: url + "/updated"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("url").
+		IsEqual(url + "/updated")
+
+	// delete link
+	e.Request(http.MethodDelete, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "link01").
+		Expect().
+		Status(http.StatusNoContent).
+		NoContent()
+
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", "link01").
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+func TestGetLinkStats(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+	otherUser := oapi.CreateUserRequestBody{
+		Username: "other_username",
+		Password: "password",
+	}
+	e.Request(http.MethodPost, "/user").
+		WithJSON(otherUser).
+		Expect().
+		Status(http.StatusOK)
+
+	linkShortenedString := "LaLiLuLeLo"
+
+	// link not found
+	e.Request(http.MethodGet, "/link/{shortened_string}/stats").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link not found"})
+
+	// create a new link
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// only the owner gets the stats
+	e.Request(http.MethodGet, "/link/{shortened_string}/stats").
+		WithBasicAuth(otherUser.Username, otherUser.Password).
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusForbidden).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "link is owned by another user"})
+
+	// click the link twice from a browser and once from curl
+	for _, userAgent := range []string{
+		"Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/111.0",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/111.0",
+		"curl/8.0.1",
+	} {
+		e.Request(http.MethodGet, "/link/{shortened_string}").
+			WithPath("shortened_string", linkShortenedString).
+			WithHeader("User-Agent", userAgent).
+			WithHeader("Referer", "https://referrer.example/").
+			WithRedirectPolicy(httpexpect.DontFollowRedirects).
+			Expect().
+			Status(http.StatusFound)
+	}
+
+	// the hour before now and the hour of now
+	to := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	from := to.Add(-2 * time.Hour)
+	getStats := func() *httpexpect.Object {
+		return e.Request(http.MethodGet, "/link/{shortened_string}/stats").
+			WithBasicAuth(user.Username, user.Password).
+			WithPath("shortened_string", linkShortenedString).
+			WithQuery("from", from.Format(time.RFC3339)).
+			WithQuery("to", to.Format(time.RFC3339)).
+			WithQuery("interval", "hour").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+	}
+
+	// clicks are recorded in the background
+	require.Eventually(t, func() bool {
+		return getStats().Value("total_clicks").Number().Raw() == 3
+	}, 5*time.Second, 50*time.Millisecond)
+
+	getStats().IsEqual(oapi.LinkStatsResponseBody{
+		From:           from,
+		To:             to,
+		Interval:       oapi.LinkStatsIntervalHour,
+		TotalClicks:    3,
+		UniqueVisitors: 1,
+		Series: []oapi.StatsBucket{
+			{Start: from, Clicks: 0, UniqueVisitors: 0},
+			{Start: from.Add(time.Hour), Clicks: 3, UniqueVisitors: 1},
+		},
+		TopReferrers: []oapi.StatsCount{
+			{Value: "https://referrer.example/", Count: 3},
+		},
+		TopUserAgents: []oapi.StatsCount{
+			{Value: "Firefox", Count: 2},
+			{Value: "curl", Count: 1},
+		},
+	})
+
+	// invalid ranges
+	e.Request(http.MethodGet, "/link/{shortened_string}/stats").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", linkShortenedString).
+		WithQuery("from", to.Format(time.RFC3339)).
+		WithQuery("to", from.Format(time.RFC3339)).
+		Expect().
+		Status(http.StatusBadRequest)
+}
+
+func TestRedirectTypes(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	follow := func(shortenedString string, status int) *httpexpect.Response {
+		response := e.Request(http.MethodGet, "/link/{shortened_string}").
+			WithPath("shortened_string", shortenedString).
+			WithRedirectPolicy(httpexpect.DontFollowRedirects).
+			Expect().
+			Status(status)
+		response.Header("Location").IsEqual(url)
+		return response
+	}
+
+	// permanent redirects are cached for a year and temporary ones never
+	redirectTypes := []struct {
+		redirectType *oapi.RedirectType
+		status       int
+		cacheControl string
+	}{
+		{nil, http.StatusFound, "no-store"},
+		{redirectType(oapi.N301), http.StatusMovedPermanently, "public, max-age=31536000"},
+		{redirectType(oapi.N302), http.StatusFound, "no-store"},
+		{redirectType(oapi.N307), http.StatusTemporaryRedirect, "no-store"},
+		{redirectType(oapi.N308), http.StatusPermanentRedirect, "public, max-age=31536000"},
+	}
+	for i, tc := range redirectTypes {
+		shortenedString := fmt.Sprintf("redirect%d", i)
+		e.Request(http.MethodPost, "/link").
+			WithBasicAuth(user.Username, user.Password).
+			WithJSON(oapi.CreateLinkRequestBody{
+				ShortenedString: &shortenedString,
+				Url:             url,
+				RedirectType:    tc.redirectType,
+			}).
+			Expect().
+			Status(http.StatusOK)
+
+		follow(shortenedString, tc.status).
+			Header(echo.HeaderCacheControl).
+			IsEqual(tc.cacheControl)
+	}
+
+	// permanent redirects of links with max clicks are never cached
+	maxClicksLink := "maxclicks"
+	maxClicks := 10
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &maxClicksLink,
+			Url:             url,
+			MaxClicks:       &maxClicks,
+			RedirectType:    redirectType(oapi.N301),
+		}).
+		Expect().
+		Status(http.StatusOK)
+	follow(maxClicksLink, http.StatusMovedPermanently).
+		Header(echo.HeaderCacheControl).
+		IsEqual("no-store")
+
+	// and are cached until links expire
+	expiringLink := "expiring"
+	expiresAt := time.Now().Add(time.Hour)
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &expiringLink,
+			Url:             url,
+			ExpiresAt:       &expiresAt,
+			RedirectType:    redirectType(oapi.N308),
+		}).
+		Expect().
+		Status(http.StatusOK)
+	follow(expiringLink, http.StatusPermanentRedirect).
+		Header(echo.HeaderCacheControl).
+		Match(`^public, max-age=(3599|3600)$`)
+
+	// an absent redirect type is kept
+	e.Request(http.MethodPatch, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "redirect1").
+		WithJSON(map[string]any{"url": url}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("redirect_type").
+		IsEqual(oapi.N301)
+	follow("redirect1", http.StatusMovedPermanently)
+
+	// a null redirect type resets the link to the server default
+	e.Request(http.MethodPatch, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "redirect1").
+		WithJSON(map[string]any{"url": url, "redirect_type": nil}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		NotContainsKey("redirect_type")
+	follow("redirect1", http.StatusFound).
+		Header(echo.HeaderCacheControl).
+		IsEqual("no-store")
+
+	// undefined redirect types are rejected
+	e.Request(http.MethodPatch, "/link/{shortened_string}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", "redirect1").
+		WithJSON(map[string]any{"url": url, "redirect_type": 303}).
+		Expect().
+		Status(http.StatusBadRequest)
+}
+
+// redirectType returns a pointer to t
+func redirectType(t oapi.RedirectType) *oapi.RedirectType {
+	return &t
+}
+
+func TestPreviewLink(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	// link not found
+	e.Request(http.MethodGet, "/link/{shortened_string}/preview").
+		WithPath("shortened_string", "undefinedLink").
+		Expect().
+		Status(http.StatusNotFound)
+
+	// urls are escaped as html
+	linkShortenedString := "LaLiLuLeLo"
+	hostileURL := url + `?q="><script>alert('x')</script>`
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             hostileURL,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	body := e.Request(http.MethodGet, "/link/{shortened_string}/preview").
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusOK).
+		ContentType("text/html").
+		Body()
+	body.Contains(
+		"<code>" + url +
+			"?q=&#34;&gt;&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;</code>",
+	)
+	body.NotContains("<script>")
+	body.NotContains(hostileURL)
+	body.Contains(`<a class="continue" href="/link/LaLiLuLeLo">`)
+	body.Contains("<dd>" + user.Username + "</dd>")
+
+	// urls of protected links are hidden
+	protectedShortenedString := "protected"
+	password := "link_password"
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &protectedShortenedString,
+			Url:             url,
+			Password:        &password,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	body = e.Request(http.MethodGet, "/link/{shortened_string}/preview").
+		WithPath("shortened_string", protectedShortenedString).
+		Expect().
+		Status(http.StatusOK).
+		Body()
+	body.Contains("Hidden, the link is password protected")
+	body.NotContains(url)
+}
+
+func TestUnlockLink(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	linkShortenedString := "LaLiLuLeLo"
+	password := "link_password"
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+			Password:        &password,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// browsers get the unlock form
+	response := e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusUnauthorized)
+	response.Header(echo.HeaderCacheControl).IsEqual("no-store")
+	body := response.ContentType("text/html").Body()
+	body.Contains(`<form method="post" action="/link/LaLiLuLeLo">`)
+	body.NotContains(`class="error"`)
+
+	// wrong passwords by the form render it again
+	e.Request(http.MethodPost, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithFormField("password", "wrong_password").
+		Expect().
+		Status(http.StatusUnauthorized).
+		ContentType("text/html").
+		Body().
+		Contains(`<p class="error">incorrect password</p>`)
+
+	// and by header are api errors
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithHeader("X-Link-Password", "wrong_password").
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "incorrect password"})
+
+	// the form redirects to the url once the password matches
+	response = e.Request(http.MethodPost, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithFormField("password", password).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusSeeOther)
+	response.Header("Location").IsEqual(url)
+	response.Header(echo.HeaderCacheControl).IsEqual("no-store")
+
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithHeader("X-Link-Password", password).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound).
+		Header("Location").
+		IsEqual(url)
+
+	// the client has 3 more failed attempts, spoofed X-Forwarded-For
+	// headers don't make it another client
+	for i := 0; i < 3; i++ {
+		e.Request(http.MethodPost, "/link/{shortened_string}").
+			WithPath("shortened_string", linkShortenedString).
+			WithHeader("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i)).
+			WithFormField("password", "wrong_password").
+			Expect().
+			Status(http.StatusUnauthorized)
+	}
+
+	response = e.Request(http.MethodPost, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithHeader("X-Forwarded-For", "203.0.113.100").
+		WithFormField("password", password).
+		Expect().
+		Status(http.StatusTooManyRequests)
+	response.Header(echo.HeaderRetryAfter).Match(`^[1-9][0-9]*$`)
+	response.Header(echo.HeaderCacheControl).IsEqual("no-store")
+	response.ContentType("text/html").
+		Body().
+		Contains("too many failed password attempts, try again later")
+
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithHeader("X-Link-Password", password).
+		Expect().
+		Status(http.StatusTooManyRequests).
+		JSON().
+		Object().
+		IsEqual(map[string]string{
+			"message": "too many failed password attempts, try again later",
+		})
+}
+
+func TestUnlockLinkTrustedProxies(t *testing.T) {
+	// requests of the test client come from the loopback
+	_, loopback, err := net.ParseCIDR("127.0.0.0/8")
+	require.NoError(t, err)
+	serverURL, url := setupWith(t, setupOptions{
+		trustedProxies: []*net.IPNet{loopback},
+	})
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	linkShortenedString := "LaLiLuLeLo"
+	password := "link_password"
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+			Password:        &password,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// clients forwarded by trusted proxies have their own attempts
+	for i := 0; i < 5; i++ {
+		e.Request(http.MethodGet, "/link/{shortened_string}").
+			WithPath("shortened_string", linkShortenedString).
+			WithHeader("X-Forwarded-For", "203.0.113.1").
+			WithHeader("X-Link-Password", "wrong_password").
+			Expect().
+			Status(http.StatusUnauthorized)
+	}
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithHeader("X-Forwarded-For", "203.0.113.1").
+		WithHeader("X-Link-Password", password).
+		Expect().
+		Status(http.StatusTooManyRequests)
+
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", linkShortenedString).
+		WithHeader("X-Forwarded-For", "203.0.113.2").
+		WithHeader("X-Link-Password", password).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound)
+}
+
+func TestCreateLinksBatch(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	usedShortenedString := "LaLiLuLeLo"
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &usedShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	newShortenedString := "newLink01"
+	links := []oapi.BatchLinkItem{
+		{ShortenedString: &newShortenedString, Url: url},
+		{ShortenedString: &usedShortenedString, Url: url + "/used"},
+	}
+	atomic := true
+
+	// unauthorized
+	e.Request(http.MethodPost, "/links:batch").
+		WithJSON(oapi.CreateLinksBatchRequestBody{Links: links}).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// any invalid link rejects the batch
+	e.Request(http.MethodPost, "/links:batch").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinksBatchRequestBody{
+			Links: append(links, oapi.BatchLinkItem{Url: "invalid|url"}),
+		}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// atomic batches create none of the links if any fails
+	e.Request(http.MethodPost, "/links:batch").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinksBatchRequestBody{
+			Atomic: &atomic,
+			Links:  links,
+		}).
+		Expect().
+		Status(http.StatusUnprocessableEntity).
+		JSON().
+		Object().
+		IsEqual(oapi.CreateLinksBatchResponseBody{
+			Created: 0,
+			Results: []oapi.BatchLinkResult{
+				{
+					Url:   url,
+					Error: stringPtr("not created as another link failed"),
+				},
+				{
+					Url:   url + "/used",
+					Error: stringPtr("shortened string have used"),
+				},
+			},
+		})
+
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", newShortenedString).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// otherwise each link is created on its own
+	results := e.Request(http.MethodPost, "/links:batch").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinksBatchRequestBody{
+			Links: append(links, oapi.BatchLinkItem{Url: url + "/generated"}),
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object()
+	results.Value("created").IsEqual(2)
+	results.Value("results").Array().Length().IsEqual(3)
+	results.Value("results").Array().Element(0).IsEqual(oapi.BatchLinkResult{
+		Url:             url,
+		Created:         true,
+		ShortenedString: &newShortenedString,
+	})
+	results.Value("results").Array().Element(1).IsEqual(oapi.BatchLinkResult{
+		Url:   url + "/used",
+		Error: stringPtr("shortened string have used"),
+	})
+	generated := results.Value("results").Array().Element(2).Object()
+	generated.Value("created").IsEqual(true)
+	generatedShortenedString := generated.Value("shortened_string").String().Raw()
+
+	for shortenedString, location := range map[string]string{
+		newShortenedString:       url,
+		usedShortenedString:      url,
+		generatedShortenedString: url + "/generated",
+	} {
+		e.Request(http.MethodGet, "/link/{shortened_string}").
+			WithPath("shortened_string", shortenedString).
+			WithRedirectPolicy(httpexpect.DontFollowRedirects).
+			Expect().
+			Status(http.StatusFound).
+			Header("Location").
+			IsEqual(location)
+	}
+
+	// the batch route does not shadow the links route
+	e.Request(http.MethodGet, "/links").
+		WithBasicAuth(user.Username, user.Password).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("total").
+		IsEqual(3)
+}
+
+func TestCreateLinkExhaustedShortCodes(t *testing.T) {
+	// every generated shortened string is used
+	generator := mockups.NewMockShortCodeGenerator(gomock.NewController(t))
+	generator.EXPECT().
+		Generate(gomock.Any(), gomock.Any()).
+		Return("used01", nil).
+		AnyTimes()
+	serverURL, url := setupWith(t, setupOptions{generator: generator})
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: stringPtr("used01"),
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	message := "no unused shortened string generated, retry or give a shortened_string"
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{Url: url}).
+		Expect().
+		Status(http.StatusServiceUnavailable).
+		JSON().
+		Object().
+		Value("message").
+		IsEqual(message)
+
+	e.Request(http.MethodPost, "/links:batch").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinksBatchRequestBody{
+			Links: []oapi.BatchLinkItem{{Url: url}},
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.CreateLinksBatchResponseBody{
+			Results: []oapi.BatchLinkResult{
+				{Url: url, Error: &message},
+			},
+		})
+}
+
+// stringPtr returns a pointer to s
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestCreateUser(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	// invalid json body
+	e.Request(http.MethodPost, "/user").
+		WithJSON("{invalid_json_body}").
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON()
+
+	// empty username and password, rejected by the request validator
+	e.Request(http.MethodPost, "/user").
+		WithJSON(map[string]any{}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		String().
+		Contains("is missing")
+
+	// invalid username, rejected by the request validator
+	e.Request(http.MethodPost, "/user").
+		WithJSON(oapi.CreateUserRequestBody{
+			Username: "un",
+			Password: "password",
+		}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		String().
+		Contains(`Error at "/username"`)
+
+	// create user
+	user := createUser(e)
+
+	// create a link
+	linkShortenedString := "LaLiLuLeLo"
+
+	e.Request(http.MethodPost, "/link").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateLinkRequestBody{
+			ShortenedString: &linkShortenedString,
+			Url:             url,
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	// assert user is created
+	e.Request(http.MethodGet, "/link/{shortened_string}/user").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("shortened_string", linkShortenedString).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.GetLinkUserResponseBody{Username: user.Username})
+
+	// username have taken
+	e.Request(http.MethodPost, "/user").
+		WithJSON(user).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "username have taken"})
+}
+
+func TestAPIKeys(t *testing.T) {
+	serverURL, _ := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	// api keys are managed by username and password
+	e.Request(http.MethodPost, "/user/api-keys").
+		WithJSON(oapi.CreateAPIKeyRequestBody{Name: "ci"}).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// first there's no api key
+	e.Request(http.MethodGet, "/user/api-keys").
+		WithBasicAuth(user.Username, user.Password).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.ListAPIKeysResponseBody{ApiKeys: []oapi.APIKey{}})
+
+	// create api key
+	created := e.Request(http.MethodPost, "/user/api-keys").
+		WithBasicAuth(user.Username, user.Password).
+		WithJSON(oapi.CreateAPIKeyRequestBody{Name: "ci"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object()
+	key := created.Value("key").String().Raw()
+	apiKey := created.Value("api_key").Object()
+	apiKey.Value("name").IsEqual("ci")
+	prefix := apiKey.Value("prefix").String().Raw()
+	require.Contains(t, key, "_"+prefix+"_")
+
+	// list api keys, the key itself is never listed
+	listed := e.Request(http.MethodGet, "/user/api-keys").
+		WithBasicAuth(user.Username, user.Password).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("api_keys").
+		Array()
+	listed.Length().IsEqual(1)
+	listed.Element(0).Object().Value("prefix").IsEqual(prefix)
+	listed.Element(0).Object().NotContainsKey("key")
+
+	// authenticate by the api key
+	e.Request(http.MethodGet, "/links").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+
+	// revoke undefined api key
+	e.Request(http.MethodDelete, "/user/api-keys/{api_key_prefix}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("api_key_prefix", "undefined").
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "api key not found"})
+
+	// revoke api key
+	e.Request(http.MethodDelete, "/user/api-keys/{api_key_prefix}").
+		WithBasicAuth(user.Username, user.Password).
+		WithPath("api_key_prefix", prefix).
+		Expect().
+		Status(http.StatusNoContent).
+		NoContent()
+
+	// revoked api key is rejected
+	e.Request(http.MethodGet, "/links").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().
+		Object().
+		IsEqual(map[string]string{"message": "invalid api key"})
+
+	e.Request(http.MethodGet, "/user/api-keys").
+		WithBasicAuth(user.Username, user.Password).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.ListAPIKeysResponseBody{ApiKeys: []oapi.APIKey{}})
+}
+
+func TestHealth(t *testing.T) {
+	serverURL, _ := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	// probes need no credentials and are not validated
+	e.Request(http.MethodGet, "/healthz").
+		WithQuery("probe", "liveness").
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.HealthResponseBody{
+			Status:     oapi.Ok,
+			Components: map[string]oapi.ComponentHealth{},
+		})
+
+	e.Request(http.MethodGet, "/readyz").
+		WithQuery("probe", "readiness").
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.HealthResponseBody{
+			Status: oapi.Ok,
+			Components: map[string]oapi.ComponentHealth{
+				health.ServerComponent: {Status: oapi.Ok},
+			},
+		})
+}
+
+func TestReadinessHidesErrors(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(context.Context) error {
+		return errors.New("dial tcp 10.0.0.1:5432: connect: connection refused")
+	})
+	checker.Add("migrations", func(context.Context) error {
+		return fmt.Errorf(
+			"%w: at version 1, expected version 2",
+			health.ErrUnexpectedMigrations,
+		)
+	})
+	checker.Drain()
+	serverURL, _ := setupWith(t, setupOptions{health: checker})
+
+	e := httpexpect.Default(t, serverURL)
+
+	// failures are described without their details
+	e.Request(http.MethodGet, "/readyz").
+		WithQuery("probe", "readiness").
+		Expect().
+		Status(http.StatusServiceUnavailable).
+		JSON().
+		Object().
+		IsEqual(oapi.HealthResponseBody{
+			Status: oapi.Failing,
+			Components: map[string]oapi.ComponentHealth{
+				"database": {
+					Status: oapi.Failing,
+					Error:  stringPtr("unreachable"),
+				},
+				"migrations": {
+					Status: oapi.Failing,
+					Error:  stringPtr("migrations at unexpected version"),
+				},
+				health.ServerComponent: {
+					Status: oapi.Failing,
+					Error:  stringPtr("shutting down"),
+				},
+			},
+		})
+}
+
+func TestImportExportLinks(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	// expired links can be imported, so exports of them import back
+	expiresAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	file := "url,shortened_string,expires_at,max_clicks\n" +
+		url + ",imported01,,\n" +
+		url + "/expired,imported02," + expiresAt.Format(time.RFC3339) + ",\n" +
+		"invalid|url,,,\n" +
+		url + ",,,many\n"
+
+	// unauthorized
+	e.Request(http.MethodPost, "/links/import").
+		WithMultipart().
+		WithFileBytes("file", "links.csv", []byte(file)).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// rows are created or reported on their own
+	e.Request(http.MethodPost, "/links/import").
+		WithBasicAuth(user.Username, user.Password).
+		WithMultipart().
+		WithFileBytes("file", "links.csv", []byte(file)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		IsEqual(oapi.ImportLinksResponseBody{
+			Created: 2,
+			Results: []oapi.ImportRowResult{
+				{Row: 1, Created: true, ShortenedString: stringPtr("imported01")},
+				{Row: 2, Created: true, ShortenedString: stringPtr("imported02")},
+				{Row: 3, Error: stringPtr("url: must be a valid URL.")},
+				{Row: 4, Error: stringPtr("max_clicks: must be an integer")},
+			},
+		})
+
+	e.Request(http.MethodGet, "/link/{shortened_string}").
+		WithPath("shortened_string", "imported01").
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound).
+		Header("Location").
+		IsEqual(url)
+
+	// exports are newest first
+	res := e.Request(http.MethodGet, "/links/export").
+		WithBasicAuth(user.Username, user.Password).
+		WithQuery("format", "json").
+		Expect().
+		Status(http.StatusOK).
+		ContentType("application/json", "UTF-8")
+	res.Header(echo.HeaderContentDisposition).
+		IsEqual(`attachment; filename="links.json"`)
+	exported := res.JSON().Array()
+	exported.Length().IsEqual(2)
+	exported.Element(0).Object().Value("shortened_string").IsEqual("imported02")
+	exported.Element(0).Object().Value("expires_at").IsEqual(expiresAt.Format(time.RFC3339))
+	exported.Element(1).Object().Value("shortened_string").IsEqual("imported01")
+
+	res = e.Request(http.MethodGet, "/links/export").
+		WithBasicAuth(user.Username, user.Password).
+		Expect().
+		Status(http.StatusOK).
+		ContentType("text/csv", "UTF-8")
+	res.Header(echo.HeaderContentDisposition).
+		IsEqual(`attachment; filename="links.csv"`)
+	csvExport := res.Body()
+	csvExport.HasPrefix(
+		"shortened_string,url,created_at,expires_at,max_clicks,click_count," +
+			"redirect_type,password_protected\nimported02," + url + "/expired,",
+	)
+	jsonExport := e.Request(http.MethodGet, "/links/export").
+		WithBasicAuth(user.Username, user.Password).
+		WithQuery("format", "json").
+		Expect().
+		Body().
+		Raw()
+
+	// exports import back once their links are gone
+	deleteLinks := func() {
+		for _, shortenedString := range []string{"imported01", "imported02"} {
+			e.Request(http.MethodDelete, "/link/{shortened_string}").
+				WithBasicAuth(user.Username, user.Password).
+				WithPath("shortened_string", shortenedString).
+				Expect().
+				Status(http.StatusNoContent)
+		}
+	}
+	for format, export := range map[string]string{
+		"csv":  csvExport.Raw(),
+		"json": jsonExport,
+	} {
+		deleteLinks()
+		e.Request(http.MethodPost, "/links/import").
+			WithBasicAuth(user.Username, user.Password).
+			WithQuery("format", format).
+			WithMultipart().
+			WithFileBytes("file", "links."+format, []byte(export)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			IsEqual(oapi.ImportLinksResponseBody{
+				Created: 2,
+				Results: []oapi.ImportRowResult{
+					{Row: 1, Created: true, ShortenedString: stringPtr("imported02")},
+					{Row: 2, Created: true, ShortenedString: stringPtr("imported01")},
+				},
+			})
+	}
+}
+
+func TestImportLinksLimits(t *testing.T) {
+	serverURL, url := setup(t)
+
+	e := httpexpect.Default(t, serverURL)
+
+	user := createUser(e)
+
+	importFile := func(format string, file []byte) *httpexpect.Response {
+		return e.Request(http.MethodPost, "/links/import").
+			WithBasicAuth(user.Username, user.Password).
+			WithQuery("format", format).
+			WithMultipart().
+			WithFileBytes("file", "links."+format, file).
+			Expect()
+	}
+
+	importFile("json", []byte(`{"url":"`+url+`"}`)).
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		IsEqual("file is not a json array")
+
+	importFile("csv", []byte("shortened_string\nimported01\n")).
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		IsEqual("file has no url column")
+
+	// files of too many rows are rejected whole
+	rows := make([]string, 10001)
+	for i := range rows {
+		rows[i] = `{"url":"` + url + `"}`
+	}
+	importFile("json", []byte("["+strings.Join(rows, ",")+"]")).
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		IsEqual("file has more than 10000 rows")
+
+	importFile("csv", []byte("url\n"+strings.Repeat(url+"\n", 10001))).
+		Status(http.StatusBadRequest).
+		JSON().
+		Object().
+		Value("message").
+		IsEqual("file has more than 10000 rows")
+
+	// bodies over the limit are not read
+	importFile("csv", []byte("url\n"+strings.Repeat("x", 16<<20))).
+		Status(http.StatusRequestEntityTooLarge).
+		JSON().
+		Object().
+		Value("message").
+		IsEqual(fmt.Sprintf("request body is larger than %d bytes", 16<<20))
+
+	e.Request(http.MethodGet, "/links").
+		WithBasicAuth(user.Username, user.Password).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("total").
+		IsEqual(0)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/httperror"
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/validate"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) GetLinkStats(
+	c echo.Context,
+	shortenedString oapi.ShortenedString,
+	params oapi.GetLinkStatsParams,
+) error {
+	// apply defaults then validate the range
+	if params.To == nil {
+		params.To = new(time.Time)
+		*params.To = time.Now()
+	}
+	if params.From == nil {
+		params.From = new(time.Time)
+		*params.From = params.To.AddDate(0, 0, -7)
+	}
+	if params.Interval == nil {
+		params.Interval = new(oapi.GetLinkStatsParamsInterval)
+		*params.Interval = oapi.GetLinkStatsParamsIntervalDay
+	}
+	if err := validate.GetLinkStatsParams(params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	user, err := authenticatedUser(c)
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.serviceUseCases.GetLinkStats(
+		c.Request().Context(),
+		user,
+		shortenedString,
+		domain.StatsInterval(*params.Interval),
+		*params.From,
+		*params.To,
+	)
+	if err != nil {
+		return httperror.New(err)
+	}
+
+	response := oapi.LinkStatsResponseBody{
+		From:           stats.From,
+		To:             stats.To,
+		Interval:       oapi.LinkStatsInterval(stats.Interval),
+		TotalClicks:    stats.TotalClicks,
+		UniqueVisitors: stats.UniqueVisitors,
+		Series:         make([]oapi.StatsBucket, 0, len(stats.Series)),
+		TopReferrers:   toStatsCounts(stats.TopReferrers),
+		TopUserAgents:  toStatsCounts(stats.TopUserAgents),
+	}
+	for _, bucket := range stats.Series {
+		response.Series = append(response.Series, oapi.StatsBucket{
+			Start:          bucket.Start,
+			Clicks:         bucket.Clicks,
+			UniqueVisitors: bucket.UniqueVisitors,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func toStatsCounts(counts []*domain.StatsCount) []oapi.StatsCount {
+	result := make([]oapi.StatsCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, oapi.StatsCount{
+			Value: count.Value,
+			Count: count.Count,
+		})
+	}
+	return result
+}
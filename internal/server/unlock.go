@@ -0,0 +1,131 @@
+package server
+
+import (
+	"bytes"
+	"html/template"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/ratelimit"
+	"github.com/labstack/echo/v4"
+)
+
+// failed password attempts allowed per link and per client, then one more
+// every attemptsInterval. links get more as clients share them
+const (
+	linkAttemptsBurst   = 20
+	clientAttemptsBurst = 5
+	attemptsInterval    = time.Minute
+)
+
+var unlockTemplate = template.Must(
+	template.ParseFS(templatesFS, "templates/unlock.html"),
+)
+
+type unlockData struct {
+	ShortenedString string
+	Action          string
+	Error           string
+}
+
+// attemptLimiters rate limit failed password attempts
+type attemptLimiters struct {
+	link   *ratelimit.Limiter
+	client *ratelimit.Limiter
+}
+
+func newAttemptLimiters() attemptLimiters {
+	return attemptLimiters{
+		link:   ratelimit.New(linkAttemptsBurst, attemptsInterval),
+		client: ratelimit.New(clientAttemptsBurst, attemptsInterval),
+	}
+}
+
+// take takes an attempt of both the link and the client, it returns how
+// long until the next attempt if either has none left
+func (l attemptLimiters) take(
+	shortenedString string,
+	clientIP string,
+	now time.Time,
+) (bool, time.Duration) {
+	ok, retryAfter := l.link.Take(shortenedString, now)
+	if !ok {
+		return false, retryAfter
+	}
+	ok, retryAfter = l.client.Take(clientIP, now)
+	if !ok {
+		l.link.Return(shortenedString, now)
+		return false, retryAfter
+	}
+	return true, 0
+}
+
+// giveBack returns an attempt which did not fail
+func (l attemptLimiters) giveBack(
+	shortenedString string,
+	clientIP string,
+	now time.Time,
+) {
+	l.link.Return(shortenedString, now)
+	l.client.Return(clientIP, now)
+}
+
+func (s *Server) UnlockLink(
+	c echo.Context,
+	shortenedString oapi.ShortenedString,
+) error {
+	return s.followLink(c, shortenedString, c.FormValue("password"), true)
+}
+
+// linkLocked renders the unlock form unless the password was given by
+// header, which only api clients do
+func (s *Server) linkLocked(
+	c echo.Context,
+	shortenedString string,
+	message string,
+	html bool,
+) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	if !html {
+		return echo.NewHTTPError(http.StatusUnauthorized, message)
+	}
+	return s.renderUnlockForm(c, http.StatusUnauthorized, shortenedString, message)
+}
+
+func (s *Server) tooManyAttempts(
+	c echo.Context,
+	shortenedString string,
+	retryAfter time.Duration,
+	html bool,
+) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.FormatInt(seconds, 10))
+	message := "too many failed password attempts, try again later"
+	if !html {
+		return echo.NewHTTPError(http.StatusTooManyRequests, message)
+	}
+	return s.renderUnlockForm(c, http.StatusTooManyRequests, shortenedString, message)
+}
+
+func (s *Server) renderUnlockForm(
+	c echo.Context,
+	code int,
+	shortenedString string,
+	message string,
+) error {
+	var page bytes.Buffer
+	err := unlockTemplate.Execute(&page, unlockData{
+		ShortenedString: shortenedString,
+		Action:          s.linkPath(shortenedString),
+		Error:           message,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).
+			SetInternal(err)
+	}
+	return c.HTMLBlob(code, page.Bytes())
+}
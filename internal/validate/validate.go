@@ -1,12 +1,26 @@
 package validate
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
 	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
 func CreateLinkRequestBody(r oapi.CreateLinkRequestBody) error {
+	return createLink(r, true)
+}
+
+// ImportedLink validates r like CreateLinkRequestBody but expires_at may be
+// in the past, so exported expired links import back
+func ImportedLink(r oapi.CreateLinkRequestBody) error {
+	return createLink(r, false)
+}
+
+func createLink(r oapi.CreateLinkRequestBody, futureExpiry bool) error {
 	return validation.ValidateStruct(
 		&r,
 		validation.Field(
@@ -23,6 +37,158 @@ func CreateLinkRequestBody(r oapi.CreateLinkRequestBody) error {
 				validation.Length(6, 32),
 			),
 		),
+		validation.Field(
+			&r.ExpiresAt,
+			validation.When(
+				r.ExpiresAt != nil && futureExpiry,
+				validation.Min(time.Now()).
+					Error("must be in the future"),
+			),
+		),
+		validation.Field(
+			&r.MaxClicks,
+			validation.When(
+				r.MaxClicks != nil,
+				validation.Min(1),
+			),
+		),
+		validation.Field(
+			&r.RedirectType,
+			validation.When(r.RedirectType != nil, redirectTypeRule),
+		),
+		validation.Field(
+			&r.Password,
+			validation.When(
+				r.Password != nil,
+				validation.Required,
+				validation.Length(8, 40),
+			),
+		),
+	)
+}
+
+// MaxBatchLinks bounds the links created by a batch request
+const MaxBatchLinks = 1000
+
+func CreateLinksBatchRequestBody(r oapi.CreateLinksBatchRequestBody) error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(
+			&r.Links,
+			validation.Required,
+			validation.Length(1, MaxBatchLinks),
+			validation.Each(validation.By(func(value interface{}) error {
+				return BatchLinkItem(value.(oapi.BatchLinkItem))
+			})),
+		),
+	)
+}
+
+func BatchLinkItem(r oapi.BatchLinkItem) error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(
+			&r.Url,
+			validation.Required,
+			is.URL,
+		),
+		validation.Field(
+			&r.ShortenedString,
+			validation.When(
+				r.ShortenedString != nil,
+				validation.Required,
+				is.Alphanumeric,
+				validation.Length(6, 32),
+			),
+		),
+	)
+}
+
+func UpdateLinkRequestBody(r oapi.UpdateLinkRequestBody) error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(
+			&r.Url,
+			validation.Required,
+			is.URL,
+		),
+		validation.Field(
+			&r.RedirectType,
+			validation.When(r.RedirectType != nil, redirectTypeRule),
+		),
+	)
+}
+
+var redirectTypeRule = validation.In(oapi.N301, oapi.N302, oapi.N307, oapi.N308)
+
+func ListLinksParams(r oapi.ListLinksParams) error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(
+			&r.Page,
+			validation.When(
+				r.Page != nil,
+				validation.Min(1),
+			),
+		),
+		validation.Field(
+			&r.PerPage,
+			validation.When(
+				r.PerPage != nil,
+				validation.Min(1),
+				validation.Max(100),
+			),
+		),
+	)
+}
+
+// maxStatsBuckets bounds the series length of a link stats response
+const maxStatsBuckets = 1000
+
+// GetLinkStatsParams validates params with defaults applied
+func GetLinkStatsParams(r oapi.GetLinkStatsParams) error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(
+			&r.Interval,
+			validation.Required,
+			validation.In(
+				oapi.GetLinkStatsParamsIntervalHour,
+				oapi.GetLinkStatsParamsIntervalDay,
+				oapi.GetLinkStatsParamsIntervalWeek,
+			),
+		),
+		validation.Field(&r.To, validation.Required),
+		validation.Field(
+			&r.From,
+			validation.Required,
+			validation.When(
+				r.To != nil,
+				validation.Max(*r.To).Exclusive().
+					Error("must be before to"),
+				validation.By(func(interface{}) error {
+					if r.Interval == nil || r.From == nil {
+						return nil
+					}
+					interval := domain.StatsInterval(*r.Interval)
+					if !interval.Valid() {
+						return nil
+					}
+					start := interval.Truncate(*r.From)
+					for i := 0; start.Before(*r.To); i++ {
+						if i == maxStatsBuckets {
+							return fmt.Errorf(
+								"range must not span more than %d %ss",
+								maxStatsBuckets,
+								interval,
+							)
+						}
+						start = interval.Next(start)
+					}
+					return nil
+				}),
+			),
+		),
 	)
 }
 
@@ -41,3 +207,22 @@ func CreateUserRequestBody(r oapi.CreateUserRequestBody) error {
 		),
 	)
 }
+
+func CreateAPIKeyRequestBody(r oapi.CreateAPIKeyRequestBody) error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(
+			&r.Name,
+			validation.Required,
+			validation.Length(1, 40),
+		),
+		validation.Field(
+			&r.ExpiresAt,
+			validation.When(
+				r.ExpiresAt != nil,
+				validation.Min(time.Now()).
+					Error("must be in the future"),
+			),
+		),
+	)
+}
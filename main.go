@@ -2,76 +2,338 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/tls"
 	"database/sql"
+	"embed"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
+	"net"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/aria3ppp/url-shortener-openapi/internal/auth"
+	"github.com/aria3ppp/url-shortener-openapi/internal/clickrecorder"
+	"github.com/aria3ppp/url-shortener-openapi/internal/config"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/domain"
+	"github.com/aria3ppp/url-shortener-openapi/internal/core/port"
 	"github.com/aria3ppp/url-shortener-openapi/internal/core/usecase"
 	"github.com/aria3ppp/url-shortener-openapi/internal/generator"
-	"github.com/aria3ppp/url-shortener-openapi/internal/oapi"
+	"github.com/aria3ppp/url-shortener-openapi/internal/hasher"
+	"github.com/aria3ppp/url-shortener-openapi/internal/health"
+	"github.com/aria3ppp/url-shortener-openapi/internal/lifecycle"
+	"github.com/aria3ppp/url-shortener-openapi/internal/linkcache"
+	"github.com/aria3ppp/url-shortener-openapi/internal/metrics"
 	"github.com/aria3ppp/url-shortener-openapi/internal/repository"
 	"github.com/aria3ppp/url-shortener-openapi/internal/server"
-	"github.com/deepmap/oapi-codegen/pkg/middleware"
-	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/labstack/echo/v4"
+	gommonlog "github.com/labstack/gommon/log"
 	_ "github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// exit codes telling a service which failed to start from one which failed
+// serving or didn't shut down cleanly
+const (
+	exitStartupFailure  = 1
+	exitShutdownFailure = 2
 )
 
 func main() {
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		os.Getenv("POSTGRES_USER"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("POSTGRES_HOST"),
-		os.Getenv("POSTGRES_PORT"),
-		os.Getenv("POSTGRES_DB"),
-	)
-	db, err := sql.Open("postgres", dsn)
+	cfg, opts, err := config.Load(os.Args[0], os.Args[1:], os.Getenv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		panic(err)
+		log.Printf("startup failed: %s", err)
+		os.Exit(exitStartupFailure)
 	}
-	if err := db.Ping(); err != nil {
-		panic(err)
+	if opts.PrintConfig {
+		fmt.Print(cfg)
+		return
 	}
 
-	repo := repository.NewRepository(db)
-	generator := generator.NewRandomStringGenerator(6)
+	// readiness probes should answer well before their own timeout
+	healthChecker := health.NewChecker(2 * time.Second)
+	app := lifecycle.New(lifecycle.Options{
+		ShutdownTimeout: cfg.Server.ShutdownTimeout,
+		DrainDelay:      cfg.Server.ShutdownDrainDelay,
+	}, healthChecker)
 
-	serviceUseCases := usecase.NewService(repo, generator)
+	e, err := setup(cfg, healthChecker, app)
+	var listener net.Listener
+	if err == nil {
+		listener, err = listen(cfg.Server)
+	}
+	if err != nil {
+		log.Printf("startup failed: %s", err)
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			cfg.Server.ShutdownTimeout,
+		)
+		if err := app.Close(ctx); err != nil {
+			log.Print(err)
+		}
+		cancel()
+		os.Exit(exitStartupFailure)
+	}
 
-	//--------------------------------------------------------------------------
+	if err := app.Serve(context.Background(), e, listener); err != nil {
+		log.Printf("shutdown failed: %s", err)
+		os.Exit(exitShutdownFailure)
+	}
+}
 
-	// handler := handler.NewHandler(serviceUseCases)
+// listen listens on the port of cfg, over tls if it's enabled
+func listen(cfg config.Server) (net.Listener, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS.Enabled() {
+		certificate, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
 
-	// router := echo.New()
-	// helper.HandleRoutes(router, handler)
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.Port))
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	return listener, nil
+}
 
-	// if err := router.Start(":" + os.Getenv("SERVER_PORT")); err != nil {
-	// 	panic(err)
-	// }
+// levels of the echo logger by config.Log.Level
+var logLevels = map[string]gommonlog.Lvl{
+	"debug": gommonlog.DEBUG,
+	"info":  gommonlog.INFO,
+	"warn":  gommonlog.WARN,
+	"error": gommonlog.ERROR,
+	"off":   gommonlog.OFF,
+}
 
-	//--------------------------------------------------------------------------
+// setup builds the service off cfg, resources to release on shutdown are
+// registered on app
+func setup(
+	cfg config.Config,
+	healthChecker *health.Checker,
+	app *lifecycle.Lifecycle,
+) (*echo.Echo, error) {
+	serviceMetrics := metrics.New()
 
-	swagger, err := oapi.GetSwagger()
-	if err != nil {
-		panic(err)
+	var repo port.Repository
+	switch cfg.Storage.Driver {
+	case "postgres":
+		db, err := sql.Open("postgres", cfg.Storage.Postgres.DSN())
+		if err != nil {
+			return nil, err
+		}
+		app.OnShutdown("database", func(context.Context) error {
+			return db.Close()
+		})
+		setPool(db, cfg.Storage.Pool)
+		if err := db.Ping(); err != nil {
+			return nil, err
+		}
+		if err := serviceMetrics.RegisterDB("postgres", db); err != nil {
+			return nil, err
+		}
+		// migrations are applied by the entrypoint before the server starts
+		migrationsCheck, err := health.Migrations(
+			db,
+			postgresMigrations,
+			"migrations",
+		)
+		if err != nil {
+			return nil, err
+		}
+		healthChecker.Add("database", db.PingContext)
+		healthChecker.Add("migrations", migrationsCheck)
+		repo = repository.NewRepository(db)
+	case "sqlite":
+		db, err := sql.Open(
+			"sqlite3",
+			"file:"+cfg.Storage.SQLite.Path+"?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL",
+		)
+		if err != nil {
+			return nil, err
+		}
+		app.OnShutdown("database", func(context.Context) error {
+			return db.Close()
+		})
+		setPool(db, cfg.Storage.Pool)
+		if err := migrateSQLite(db); err != nil {
+			return nil, err
+		}
+		if err := serviceMetrics.RegisterDB("sqlite", db); err != nil {
+			return nil, err
+		}
+		migrationsCheck, err := health.Migrations(
+			db,
+			sqliteMigrations,
+			"migrations-sqlite",
+		)
+		if err != nil {
+			return nil, err
+		}
+		healthChecker.Add("database", db.PingContext)
+		healthChecker.Add("migrations", migrationsCheck)
+		repo = repository.NewSQLiteRepository(db)
+	case "memory":
+		// everything is lost on exit
+		repo = repository.NewMemoryRepository()
 	}
-	// swagger.Servers = nil
 
-	serverImpl := server.New(serviceUseCases)
+	// latencies are of the storage, links read off the cache aren't counted
+	repo = metrics.NewRepository(repo, serviceMetrics)
+	repo = repository.NewTimeoutRepository(repo, cfg.Storage.QueryTimeout)
 
-	e := echo.New()
-	e.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
-		Options: openapi3filter.Options{
-			AuthenticationFunc: func(ctx context.Context, ai *openapi3filter.AuthenticationInput) error {
-				return nil
+	// instances sharing the storage should share a redis cache so they see
+	// each other's changes at once
+	cacheOptions := repository.CacheOptions{
+		TTL:         cfg.Cache.TTL,
+		NegativeTTL: cfg.Cache.NegativeTTL,
+	}
+	switch cfg.Cache.Driver {
+	case "memory":
+		repo = repository.NewCachedRepository(
+			repo,
+			metrics.NewLinkCache(linkcache.NewLRU(cfg.Cache.Size), serviceMetrics),
+			cacheOptions,
+		)
+	case "redis":
+		redisOptions := linkcache.DefaultRedisOptions
+		redisOptions.Addr = cfg.Cache.Redis.Addr
+		redisOptions.Password = cfg.Cache.Redis.Password
+		redisOptions.DB = cfg.Cache.Redis.DB
+		redisCache := linkcache.NewRedis(redisOptions)
+		app.OnShutdown("cache", lifecycle.Wait(redisCache.Close))
+		healthChecker.Add("cache", redisCache.Ping)
+		repo = repository.NewCachedRepository(
+			repo,
+			metrics.NewLinkCache(redisCache, serviceMetrics),
+			cacheOptions,
+		)
+	}
+
+	alphabet := cfg.Generator.AlphabetChars()
+	var shortCodeGenerator port.ShortCodeGenerator
+	switch cfg.Generator.Kind {
+	case "random":
+		shortCodeGenerator = generator.NewSecureRandomStringGenerator(
+			cfg.Generator.Length,
+			alphabet,
+		)
+	case "sequence":
+		// codes are obfuscated if a key is given
+		shortCodeGenerator = generator.NewSequenceGenerator(
+			repo,
+			generator.SequenceOptions{
+				Length:    cfg.Generator.Length,
+				Alphabet:  alphabet,
+				BlockSize: int64(cfg.Generator.SequenceBlockSize),
+				Key:       []byte(cfg.Generator.SequenceKey),
 			},
-		},
-	}))
+		)
+	}
+
+	shortCodeGenerator = metrics.NewGenerator(shortCodeGenerator, serviceMetrics)
+
+	var passwordHasher port.PasswordHasher
+	switch cfg.Users.PasswordHasher {
+	case "bcrypt":
+		passwordHasher = hasher.NewBcryptHasher(bcrypt.DefaultCost)
+	case "argon2id":
+		passwordHasher = hasher.NewArgon2idHasher(hasher.DefaultArgon2idParams)
+	}
+
+	serviceUseCases := metrics.NewService(
+		usecase.NewService(repo, shortCodeGenerator, passwordHasher),
+		serviceMetrics,
+	)
+
+	// clicks ip hashes are only comparable between runs sharing the same key
+	ipHashKey := []byte(cfg.Links.ClickIPHashKey)
+	if len(ipHashKey) == 0 {
+		ipHashKey = make([]byte, 32)
+		if _, err := rand.Read(ipHashKey); err != nil {
+			return nil, err
+		}
+	}
+	clickRecorder := clickrecorder.NewBatchRecorder(
+		repo,
+		ipHashKey,
+		clickrecorder.DefaultOptions,
+	)
+	// queued clicks are saved once no request records any more
+	app.OnShutdown("click recorder", lifecycle.Wait(clickRecorder.Close))
+
+	trustedProxies, err := cfg.Server.TrustedProxyNets()
+	if err != nil {
+		return nil, err
+	}
+	e, err := server.NewRouter(
+		server.New(
+			serviceUseCases,
+			clickRecorder,
+			domain.RedirectType(cfg.Links.DefaultRedirectType),
+			healthChecker,
+			cfg.Server.BaseURL,
+		),
+		auth.NewAuthenticator(serviceUseCases),
+		serviceMetrics,
+		trustedProxies,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Logger.SetLevel(logLevels[cfg.Log.Level])
+	return e, nil
+}
+
+func setPool(db *sql.DB, cfg config.Pool) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+//go:embed migrations-sqlite/*.sql
+var sqliteMigrations embed.FS
 
-	oapi.RegisterHandlers(e, serverImpl)
+// postgresMigrations are only checked, they're applied by migrate
+//
+//go:embed migrations/*.sql
+var postgresMigrations embed.FS
 
-	if err := e.Start(":" + os.Getenv("SERVER_PORT")); err != nil {
-		panic(err)
+// migrateSQLite applies the embedded migrations so a sqlite deployment is a
+// single binary
+func migrateSQLite(db *sql.DB) error {
+	source, err := iofs.New(sqliteMigrations, "migrations-sqlite")
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return err
+	}
+	migrator, err := migrate.NewWithInstance("iofs", source, "sqlite3", driver)
+	if err != nil {
+		return err
+	}
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
 	}
+	return nil
 }
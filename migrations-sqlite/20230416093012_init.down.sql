@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS clicks;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS links;
+DROP TABLE IF EXISTS users;
\ No newline at end of file
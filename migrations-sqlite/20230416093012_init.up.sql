@@ -0,0 +1,46 @@
+-- sqlite schema mirroring the postgres migrations. times are saved as utc
+-- text by the repository so they sort and compare lexicographically
+
+CREATE TABLE IF NOT EXISTS users (
+    username VARCHAR(40) PRIMARY KEY,
+    password VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS links (
+    shortened_string VARCHAR(40) PRIMARY KEY,
+    url VARCHAR(500) NOT NULL,
+    username VARCHAR(40) NOT NULL REFERENCES users(username),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP,
+    max_clicks INTEGER CHECK (max_clicks > 0),
+    click_count INTEGER NOT NULL DEFAULT 0
+);
+
+-- list user links newest first
+CREATE INDEX IF NOT EXISTS links_username_created_at_idx
+    ON links (username, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    prefix VARCHAR(16) PRIMARY KEY,
+    hash VARCHAR(64) NOT NULL,
+    name VARCHAR(40) NOT NULL,
+    username VARCHAR(40) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_username_idx ON api_keys (username);
+
+CREATE TABLE IF NOT EXISTS clicks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    shortened_string VARCHAR(40) NOT NULL REFERENCES links(shortened_string) ON DELETE CASCADE,
+    referrer VARCHAR(2048) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL,
+    user_agent_family VARCHAR(40) NOT NULL DEFAULT 'Unknown',
+    ip_hash VARCHAR(64) NOT NULL,
+    clicked_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS clicks_shortened_string_clicked_at_idx
+    ON clicks (shortened_string, clicked_at);
\ No newline at end of file
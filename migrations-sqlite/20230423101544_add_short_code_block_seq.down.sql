@@ -0,0 +1 @@
+DROP TABLE IF EXISTS short_code_block_seq;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- single row counter standing for a postgres sequence
+CREATE TABLE IF NOT EXISTS short_code_block_seq (
+    value INTEGER NOT NULL
+);
+
+INSERT INTO short_code_block_seq (value) VALUES (0);
\ No newline at end of file
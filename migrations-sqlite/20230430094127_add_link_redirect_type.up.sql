@@ -0,0 +1,4 @@
+-- links without a redirect type use the server default
+ALTER TABLE links
+    ADD COLUMN redirect_type INTEGER
+    CHECK (redirect_type IN (301, 302, 307, 308));
\ No newline at end of file
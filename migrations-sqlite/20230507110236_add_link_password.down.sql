@@ -0,0 +1 @@
+ALTER TABLE links DROP COLUMN password;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- encoded hash of the link's access password, links without one are public
+ALTER TABLE links
+    ADD COLUMN password TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- fails if any stored password hash is longer than 40 characters
+ALTER TABLE IF EXISTS users
+    ALTER COLUMN password TYPE VARCHAR(40);
+
+COMMIT;
\ No newline at end of file
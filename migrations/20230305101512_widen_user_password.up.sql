@@ -0,0 +1,7 @@
+BEGIN;
+
+-- widen password column to hold encoded password hashes
+ALTER TABLE IF EXISTS users
+    ALTER COLUMN password TYPE VARCHAR(255);
+
+COMMIT;
\ No newline at end of file
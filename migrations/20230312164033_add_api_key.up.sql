@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    prefix VARCHAR(16) PRIMARY KEY,
+    hash VARCHAR(64) NOT NULL,
+    name VARCHAR(40) NOT NULL,
+    username VARCHAR(40) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+-- add username foreign key constraint
+ALTER TABLE IF EXISTS api_keys
+    ADD CONSTRAINT api_keys_fk_users
+    FOREIGN KEY (username)
+    REFERENCES users(username)
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS api_keys_username_idx ON api_keys (username);
+
+COMMIT;
\ No newline at end of file
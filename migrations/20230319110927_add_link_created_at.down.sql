@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS links_username_created_at_idx;
+
+ALTER TABLE IF EXISTS links
+    DROP COLUMN IF EXISTS created_at;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS links
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- list user links newest first
+CREATE INDEX IF NOT EXISTS links_username_created_at_idx
+    ON links (username, created_at DESC);
+
+COMMIT;
\ No newline at end of file
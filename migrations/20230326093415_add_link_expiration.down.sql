@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS links
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS max_clicks,
+    DROP COLUMN IF EXISTS click_count;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS links
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS max_clicks INTEGER CHECK (max_clicks > 0),
+    ADD COLUMN IF NOT EXISTS click_count INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS clicks (
+    id BIGSERIAL PRIMARY KEY,
+    shortened_string VARCHAR(40) NOT NULL,
+    referrer VARCHAR(2048) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL,
+    ip_hash VARCHAR(64) NOT NULL,
+    clicked_at TIMESTAMPTZ NOT NULL
+);
+
+-- add shortened string foreign key constraint
+ALTER TABLE IF EXISTS clicks
+    ADD CONSTRAINT clicks_fk_links
+    FOREIGN KEY (shortened_string)
+    REFERENCES links(shortened_string)
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS clicks_shortened_string_clicked_at_idx
+    ON clicks (shortened_string, clicked_at);
+
+COMMIT;
\ No newline at end of file
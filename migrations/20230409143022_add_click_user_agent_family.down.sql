@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS clicks
+    DROP COLUMN IF EXISTS user_agent_family;
+
+COMMIT;
\ No newline at end of file
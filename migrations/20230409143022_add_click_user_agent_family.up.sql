@@ -0,0 +1,7 @@
+BEGIN;
+
+-- clicks recorded before families were introduced stay unclassified
+ALTER TABLE IF EXISTS clicks
+    ADD COLUMN IF NOT EXISTS user_agent_family VARCHAR(40) NOT NULL DEFAULT 'Unknown';
+
+COMMIT;
\ No newline at end of file
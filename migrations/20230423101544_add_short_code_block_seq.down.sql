@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP SEQUENCE IF EXISTS short_code_block_seq;
+
+COMMIT;
\ No newline at end of file
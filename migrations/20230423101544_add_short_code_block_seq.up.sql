@@ -0,0 +1,6 @@
+BEGIN;
+
+-- numbers of the id blocks reserved by sequence short code generators
+CREATE SEQUENCE IF NOT EXISTS short_code_block_seq;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE IF EXISTS links
+    DROP COLUMN IF EXISTS redirect_type;
+
+COMMIT;
\ No newline at end of file
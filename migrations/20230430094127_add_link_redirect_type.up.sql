@@ -0,0 +1,8 @@
+BEGIN;
+
+-- links without a redirect type use the server default
+ALTER TABLE IF EXISTS links
+    ADD COLUMN IF NOT EXISTS redirect_type SMALLINT
+    CHECK (redirect_type IN (301, 302, 307, 308));
+
+COMMIT;
\ No newline at end of file
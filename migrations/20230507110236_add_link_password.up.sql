@@ -0,0 +1,7 @@
+BEGIN;
+
+-- encoded hash of the link's access password, links without one are public
+ALTER TABLE IF EXISTS links
+    ADD COLUMN IF NOT EXISTS password VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMIT;
\ No newline at end of file
@@ -3,18 +3,207 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.12.4 DO NOT EDIT.
 package client
 
+import (
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
 const (
+	Api_keyScopes           = "api_key.Scopes"
 	Username_passwordScopes = "username_password.Scopes"
 )
 
+// Defines values for HealthStatus.
+const (
+	Failing HealthStatus = "failing"
+	Ok      HealthStatus = "ok"
+)
+
+// Defines values for LinkStatsInterval.
+const (
+	LinkStatsIntervalDay  LinkStatsInterval = "day"
+	LinkStatsIntervalHour LinkStatsInterval = "hour"
+	LinkStatsIntervalWeek LinkStatsInterval = "week"
+)
+
+// Defines values for RedirectType.
+const (
+	N301 RedirectType = 301
+	N302 RedirectType = 302
+	N307 RedirectType = 307
+	N308 RedirectType = 308
+)
+
+// Defines values for LinksFileFormat.
+const (
+	LinksFileFormatCsv  LinksFileFormat = "csv"
+	LinksFileFormatJson LinksFileFormat = "json"
+)
+
+// Defines values for GetLinkStatsParamsInterval.
+const (
+	GetLinkStatsParamsIntervalDay  GetLinkStatsParamsInterval = "day"
+	GetLinkStatsParamsIntervalHour GetLinkStatsParamsInterval = "hour"
+	GetLinkStatsParamsIntervalWeek GetLinkStatsParamsInterval = "week"
+)
+
+// Defines values for ExportLinksParamsFormat.
+const (
+	ExportLinksParamsFormatCsv  ExportLinksParamsFormat = "csv"
+	ExportLinksParamsFormatJson ExportLinksParamsFormat = "json"
+)
+
+// Defines values for ImportLinksParamsFormat.
+const (
+	Csv  ImportLinksParamsFormat = "csv"
+	Json ImportLinksParamsFormat = "json"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+}
+
+// BatchLinkItem defines model for BatchLinkItem.
+type BatchLinkItem struct {
+	ShortenedString *string `json:"shortened_string,omitempty"`
+	Url             string  `json:"url"`
+}
+
+// BatchLinkResult defines model for BatchLinkResult.
+type BatchLinkResult struct {
+	Created bool `json:"created"`
+
+	// Error why the link is not created
+	Error *string `json:"error,omitempty"`
+
+	// ShortenedString the shortened string of the link if created
+	ShortenedString *string `json:"shortened_string,omitempty"`
+	Url             string  `json:"url"`
+}
+
+// ComponentHealth defines model for ComponentHealth.
+type ComponentHealth struct {
+	Error  *string      `json:"error,omitempty"`
+	Status HealthStatus `json:"status"`
+}
+
+// Health defines model for Health.
+type Health struct {
+	Components map[string]ComponentHealth `json:"components"`
+	Status     HealthStatus               `json:"status"`
+}
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
+
+// ImportRowResult defines model for ImportRowResult.
+type ImportRowResult struct {
+	Created bool `json:"created"`
+
+	// Error why the link is not created
+	Error *string `json:"error,omitempty"`
+
+	// Row number of the row in the file starting at 1, not counting the csv header
+	Row int `json:"row"`
+
+	// ShortenedString the shortened string of the link if created
+	ShortenedString *string `json:"shortened_string,omitempty"`
+}
+
+// Link defines model for Link.
+type Link struct {
+	ClickCount        int        `json:"click_count"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	MaxClicks         *int       `json:"max_clicks,omitempty"`
+	PasswordProtected bool       `json:"password_protected"`
+
+	// RedirectType http status code of the redirect
+	RedirectType    *RedirectType `json:"redirect_type,omitempty"`
+	ShortenedString string        `json:"shortened_string"`
+	Url             string        `json:"url"`
+	Username        string        `json:"username"`
+}
+
+// LinkStats defines model for LinkStats.
+type LinkStats struct {
+	From         time.Time         `json:"from"`
+	Interval     LinkStatsInterval `json:"interval"`
+	Series       []StatsBucket     `json:"series"`
+	To           time.Time         `json:"to"`
+	TopReferrers []StatsCount      `json:"top_referrers"`
+
+	// TopUserAgents user agent families such as Chrome, Firefox or Bot
+	TopUserAgents []StatsCount `json:"top_user_agents"`
+	TotalClicks   int          `json:"total_clicks"`
+
+	// UniqueVisitors number of distinct client ip hashes
+	UniqueVisitors int `json:"unique_visitors"`
+}
+
+// LinkStatsInterval defines model for LinkStats.Interval.
+type LinkStatsInterval string
+
+// RedirectType http status code of the redirect
+type RedirectType int
+
+// StatsBucket defines model for StatsBucket.
+type StatsBucket struct {
+	Clicks         int       `json:"clicks"`
+	Start          time.Time `json:"start"`
+	UniqueVisitors int       `json:"unique_visitors"`
+}
+
+// StatsCount defines model for StatsCount.
+type StatsCount struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+// ApiKeyPrefix defines model for api_key_prefix.
+type ApiKeyPrefix = string
+
+// LinkPassword defines model for link_password.
+type LinkPassword = string
+
+// LinksFileFormat defines model for links_file_format.
+type LinksFileFormat string
+
 // ShortenedString defines model for shortened_string.
 type ShortenedString = string
 
+// CreateAPIKeyResponseBody defines model for CreateAPIKeyResponseBody.
+type CreateAPIKeyResponseBody struct {
+	ApiKey APIKey `json:"api_key"`
+
+	// Key the api key secret which is only returned once
+	Key string `json:"key"`
+}
+
 // CreateLinkResponseBody defines model for CreateLinkResponseBody.
 type CreateLinkResponseBody struct {
-	ShortenedString string `json:"shortened_string"`
-	Url             string `json:"url"`
-	Username        string `json:"username"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	MaxClicks         *int       `json:"max_clicks,omitempty"`
+	PasswordProtected bool       `json:"password_protected"`
+
+	// RedirectType http status code of the redirect
+	RedirectType    *RedirectType `json:"redirect_type,omitempty"`
+	ShortenedString string        `json:"shortened_string"`
+	Url             string        `json:"url"`
+	Username        string        `json:"username"`
+}
+
+// CreateLinksBatchResponseBody defines model for CreateLinksBatchResponseBody.
+type CreateLinksBatchResponseBody struct {
+	// Created number of links created
+	Created int               `json:"created"`
+	Results []BatchLinkResult `json:"results"`
 }
 
 // ErrorResponseBody defines model for ErrorResponseBody.
@@ -28,10 +217,73 @@ type GetLinkUserResponseBody struct {
 	Username string `json:"username"`
 }
 
+// HealthResponseBody defines model for HealthResponseBody.
+type HealthResponseBody = Health
+
+// ImportLinksResponseBody defines model for ImportLinksResponseBody.
+type ImportLinksResponseBody struct {
+	// Created number of links created
+	Created int               `json:"created"`
+	Results []ImportRowResult `json:"results"`
+}
+
+// LinkLocked defines model for LinkLocked.
+type LinkLocked struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// LinkResponseBody defines model for LinkResponseBody.
+type LinkResponseBody = Link
+
+// LinkStatsResponseBody defines model for LinkStatsResponseBody.
+type LinkStatsResponseBody = LinkStats
+
+// ListAPIKeysResponseBody defines model for ListAPIKeysResponseBody.
+type ListAPIKeysResponseBody struct {
+	ApiKeys []APIKey `json:"api_keys"`
+}
+
+// ListLinksResponseBody defines model for ListLinksResponseBody.
+type ListLinksResponseBody struct {
+	Links   []Link `json:"links"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+	Total   int    `json:"total"`
+}
+
+// TooManyAttempts defines model for TooManyAttempts.
+type TooManyAttempts struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// CreateAPIKeyRequestBody defines model for CreateAPIKeyRequestBody.
+type CreateAPIKeyRequestBody struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+}
+
 // CreateLinkRequestBody defines model for CreateLinkRequestBody.
 type CreateLinkRequestBody struct {
-	ShortenedString *string `json:"shortened_string,omitempty"`
-	Url             string  `json:"url"`
+	// ExpiresAt the link stops redirecting at this time
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// MaxClicks the link stops redirecting after this many clicks
+	MaxClicks *int `json:"max_clicks,omitempty"`
+
+	// Password the link only redirects to who knows this password
+	Password *string `json:"password,omitempty"`
+
+	// RedirectType http status code of the redirect
+	RedirectType    *RedirectType `json:"redirect_type,omitempty"`
+	ShortenedString *string       `json:"shortened_string,omitempty"`
+	Url             string        `json:"url"`
+}
+
+// CreateLinksBatchRequestBody defines model for CreateLinksBatchRequestBody.
+type CreateLinksBatchRequestBody struct {
+	// Atomic create every link or none
+	Atomic *bool           `json:"atomic,omitempty"`
+	Links  []BatchLinkItem `json:"links"`
 }
 
 // CreateUserRequestBody defines model for CreateUserRequestBody.
@@ -40,10 +292,93 @@ type CreateUserRequestBody struct {
 	Username string `json:"username"`
 }
 
+// UpdateLinkRequestBody defines model for UpdateLinkRequestBody.
+type UpdateLinkRequestBody struct {
+	// RedirectType kept if absent, null resets the link to the server default
+	RedirectType *RedirectType `json:"redirect_type"`
+	Url          string        `json:"url"`
+}
+
 // CreateLinkJSONBody defines parameters for CreateLink.
 type CreateLinkJSONBody struct {
-	ShortenedString *string `json:"shortened_string,omitempty"`
-	Url             string  `json:"url"`
+	// ExpiresAt the link stops redirecting at this time
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// MaxClicks the link stops redirecting after this many clicks
+	MaxClicks *int `json:"max_clicks,omitempty"`
+
+	// Password the link only redirects to who knows this password
+	Password *string `json:"password,omitempty"`
+
+	// RedirectType http status code of the redirect
+	RedirectType    *RedirectType `json:"redirect_type,omitempty"`
+	ShortenedString *string       `json:"shortened_string,omitempty"`
+	Url             string        `json:"url"`
+}
+
+// GetLinkParams defines parameters for GetLink.
+type GetLinkParams struct {
+	// XLinkPassword password of a password protected link
+	XLinkPassword *LinkPassword `json:"X-Link-Password,omitempty"`
+}
+
+// UpdateLinkJSONBody defines parameters for UpdateLink.
+type UpdateLinkJSONBody struct {
+	// RedirectType kept if absent, null resets the link to the server default
+	RedirectType *RedirectType `json:"redirect_type"`
+	Url          string        `json:"url"`
+}
+
+// UnlockLinkFormdataBody defines parameters for UnlockLink.
+type UnlockLinkFormdataBody struct {
+	Password string `json:"password"`
+}
+
+// GetLinkStatsParams defines parameters for GetLinkStats.
+type GetLinkStatsParams struct {
+	// From defaults to 7 days before to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To defaults to now
+	To       *time.Time                  `form:"to,omitempty" json:"to,omitempty"`
+	Interval *GetLinkStatsParamsInterval `form:"interval,omitempty" json:"interval,omitempty"`
+}
+
+// GetLinkStatsParamsInterval defines parameters for GetLinkStats.
+type GetLinkStatsParamsInterval string
+
+// ListLinksParams defines parameters for ListLinks.
+type ListLinksParams struct {
+	Page    *int `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// ExportLinksParams defines parameters for ExportLinks.
+type ExportLinksParams struct {
+	Format *ExportLinksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportLinksParamsFormat defines parameters for ExportLinks.
+type ExportLinksParamsFormat string
+
+// ImportLinksMultipartBody defines parameters for ImportLinks.
+type ImportLinksMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// ImportLinksParams defines parameters for ImportLinks.
+type ImportLinksParams struct {
+	Format *ImportLinksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ImportLinksParamsFormat defines parameters for ImportLinks.
+type ImportLinksParamsFormat string
+
+// CreateLinksBatchJSONBody defines parameters for CreateLinksBatch.
+type CreateLinksBatchJSONBody struct {
+	// Atomic create every link or none
+	Atomic *bool           `json:"atomic,omitempty"`
+	Links  []BatchLinkItem `json:"links"`
 }
 
 // CreateUserJSONBody defines parameters for CreateUser.
@@ -52,8 +387,29 @@ type CreateUserJSONBody struct {
 	Username string `json:"username"`
 }
 
+// CreateApiKeyJSONBody defines parameters for CreateApiKey.
+type CreateApiKeyJSONBody struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+}
+
 // CreateLinkJSONRequestBody defines body for CreateLink for application/json ContentType.
 type CreateLinkJSONRequestBody CreateLinkJSONBody
 
+// UpdateLinkJSONRequestBody defines body for UpdateLink for application/json ContentType.
+type UpdateLinkJSONRequestBody UpdateLinkJSONBody
+
+// UnlockLinkFormdataRequestBody defines body for UnlockLink for application/x-www-form-urlencoded ContentType.
+type UnlockLinkFormdataRequestBody UnlockLinkFormdataBody
+
+// ImportLinksMultipartRequestBody defines body for ImportLinks for multipart/form-data ContentType.
+type ImportLinksMultipartRequestBody ImportLinksMultipartBody
+
+// CreateLinksBatchJSONRequestBody defines body for CreateLinksBatch for application/json ContentType.
+type CreateLinksBatchJSONRequestBody CreateLinksBatchJSONBody
+
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody CreateUserJSONBody
+
+// CreateApiKeyJSONRequestBody defines body for CreateApiKey for application/json ContentType.
+type CreateApiKeyJSONRequestBody CreateApiKeyJSONBody